@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	auditCreate  = "criar"
+	auditUpdate  = "editar"
+	auditDelete  = "deletar"
+	auditRestore = "restaurar"
+	auditPurge   = "excluir permanentemente"
+)
+
+const auditListLimit = 500
+
+type AuditLog struct {
+	ID        uint      `gorm:"primaryKey"`
+	CreatedAt time.Time `gorm:"index"`
+	UserID    *uint
+	Username  string
+	Action    string `gorm:"not null"`
+	Entity    string `gorm:"not null"`
+	EntityID  uint
+	Details   string
+}
+
+func recordAudit(action, entity string, entityID uint, details string) {
+	entry := AuditLog{Action: action, Entity: entity, EntityID: entityID, Details: details}
+	if currentUser != nil {
+		entry.UserID = &currentUser.ID
+		entry.Username = currentUser.Username
+	}
+	slog.Info("Operação registrada", "acao", action, "entidade", entity, "id", entityID, "usuario", entry.Username, "detalhes", details)
+	if err := db.Create(&entry).Error; err != nil {
+		slog.Error("Erro ao registrar auditoria", "acao", action, "entidade", entity, "id", entityID, "erro", err)
+	}
+}
+
+func loadAuditLogs(start, end time.Time) []AuditLog {
+	var logs []AuditLog
+	query := db.Order("created_at desc").Limit(auditListLimit)
+	if !start.IsZero() {
+		query = query.Where("created_at >= ? AND created_at < ?", start, end.AddDate(0, 0, 1))
+	}
+	query.Find(&logs)
+	return logs
+}
+
+func auditTab(w fyne.Window) fyne.CanvasObject {
+	startPicker := NewDatePicker()
+	endPicker := NewDatePicker()
+	summaryLabel := widget.NewLabel("")
+
+	var logs []AuditLog
+	headers := []string{T("audit.time"), T("audit.user"), T("audit.action"), T("audit.entity"), "ID", T("audit.details")}
+
+	table := widget.NewTable(
+		func() (int, int) {
+			return len(logs) + 1, len(headers)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("template template")
+		},
+		func(id widget.TableCellID, co fyne.CanvasObject) {
+			label := co.(*widget.Label)
+			label.TextStyle = fyne.TextStyle{}
+			if id.Row == 0 {
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				label.SetText(headers[id.Col])
+				return
+			}
+			entry := logs[id.Row-1]
+			switch id.Col {
+			case 0:
+				label.SetText(entry.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+			case 1:
+				if entry.Username == "" {
+					label.SetText("-")
+				} else {
+					label.SetText(entry.Username)
+				}
+			case 2:
+				label.SetText(entry.Action)
+			case 3:
+				label.SetText(entry.Entity)
+			case 4:
+				label.SetText(fmt.Sprintf("%d", entry.EntityID))
+			case 5:
+				label.SetText(entry.Details)
+			}
+		},
+	)
+	table.SetColumnWidth(0, 170)
+	table.SetColumnWidth(1, 140)
+	table.SetColumnWidth(2, 80)
+	table.SetColumnWidth(3, 120)
+	table.SetColumnWidth(4, 60)
+	table.SetColumnWidth(5, 360)
+
+	show := func(start, end time.Time) {
+		logs = loadAuditLogs(start, end)
+		if len(logs) == 0 {
+			summaryLabel.SetText(T("audit.none"))
+		} else {
+			summaryLabel.SetText(T("audit.found", len(logs)))
+		}
+		table.Refresh()
+	}
+
+	filterBtn := newButton(T("audit.filter"), func() {
+		start, end, err := readDateRange(startPicker, endPicker)
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		show(start, end)
+	})
+	recentBtn := newButton(T("audit.recent"), func() {
+		startPicker.Clear()
+		endPicker.Clear()
+		show(time.Time{}, time.Time{})
+	})
+	show(time.Time{}, time.Time{})
+
+	top := container.NewVBox(
+		widget.NewForm(
+			widget.NewFormItem(T("common.start_date"), startPicker),
+			widget.NewFormItem(T("common.end_date"), endPicker),
+		),
+		container.NewHBox(filterBtn, recentBtn),
+		summaryLabel,
+	)
+	return container.NewBorder(top, nil, nil, nil, table)
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+var sqlitePath string
+
+func backupFileName() string {
+	ext := ".sql"
+	if dbDriver == "sqlite" {
+		ext = ".db"
+	}
+	return fmt.Sprintf("backup_cotacao_%s%s", time.Now().Format("20060102_150405"), ext)
+}
+
+func postgresEnv() []string {
+	return append(os.Environ(), "PGPASSWORD="+os.Getenv("DB_PASSWORD"))
+}
+
+func postgresArgs() []string {
+	return []string{"-h", os.Getenv("DB_HOST"), "-p", os.Getenv("DB_PORT"), "-U", os.Getenv("DB_USER"), "-d", os.Getenv("DB_NAME")}
+}
+
+func runTool(cmd *exec.Cmd) error {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	cmd.Env = postgresEnv()
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return errors.New(T("backup.command_failed", filepath.Base(cmd.Path), msg))
+	}
+	return nil
+}
+
+func backupDatabase(out io.Writer) error {
+	if dbDriver == "sqlite" {
+		tmp, err := os.MkdirTemp("", "cotacao_backup")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tmp)
+		snapshot := filepath.Join(tmp, "backup.db")
+		if err := db.Exec("VACUUM INTO ?", snapshot).Error; err != nil {
+			return errors.New(T("backup.sqlite_copy_error", err))
+		}
+		f, err := os.Open(snapshot)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(out, f)
+		return err
+	}
+
+	args := append(postgresArgs(), "--clean", "--if-exists", "--no-owner")
+	cmd := exec.Command("pg_dump", args...)
+	cmd.Stdout = out
+	return runTool(cmd)
+}
+
+const sqliteHeader = "SQLite format 3\x00"
+
+// No SQLite o backup é só gravado num arquivo temporário ao lado do banco; a
+// troca é feita por swapSQLiteDatabase na thread da interface.
+func restoreDatabase(in io.Reader) (string, error) {
+	if dbDriver == "sqlite" {
+		return stageSQLiteRestore(in)
+	}
+
+	args := append(postgresArgs(), "-v", "ON_ERROR_STOP=1", "-q")
+	cmd := exec.Command("psql", args...)
+	cmd.Stdin = in
+	return "", runTool(cmd)
+}
+
+func stageSQLiteRestore(in io.Reader) (string, error) {
+	header := make([]byte, len(sqliteHeader))
+	if _, err := io.ReadFull(in, header); err != nil || string(header) != sqliteHeader {
+		return "", errors.New(T("backup.invalid_sqlite"))
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(sqlitePath), filepath.Base(sqlitePath)+".restore-*")
+	if err != nil {
+		return "", errors.New(T("backup.temp_file_error", err))
+	}
+	_, err = tmp.Write(header)
+	if err == nil {
+		_, err = io.Copy(tmp, in)
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return "", errors.New(T("backup.write_error", err))
+	}
+	return tmp.Name(), nil
+}
+
+func swapSQLiteDatabase(staged string) error {
+	db.Exec("PRAGMA wal_checkpoint(TRUNCATE)")
+	if sqlDB, err := db.DB(); err == nil {
+		sqlDB.Close()
+	}
+	var swapErr error
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(sqlitePath + suffix); err != nil && !os.IsNotExist(err) {
+			swapErr = err
+		}
+	}
+	if swapErr == nil {
+		swapErr = os.Rename(staged, sqlitePath)
+	}
+	if swapErr != nil {
+		os.Remove(staged)
+	}
+
+	conn, err := openWithRetry()
+	if err != nil {
+		return errors.New(T("backup.reopen_error", err))
+	}
+	db = conn
+	if swapErr != nil {
+		return errors.New(T("backup.swap_error", swapErr))
+	}
+	return nil
+}
+
+func finishRestore(staged string) error {
+	if staged != "" {
+		if err := swapSQLiteDatabase(staged); err != nil {
+			return err
+		}
+	}
+	return migrateDatabase()
+}
+
+func backupTab(w fyne.Window) fyne.CanvasObject {
+	backupBtn := newButton(T("backup.create"), func() {
+		if !connectionAvailable(w) {
+			return
+		}
+		saveDlg := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			if writer == nil {
+				return
+			}
+			var backupErr error
+			runWithProgress(w, T("backup.creating"), func() {
+				backupErr = backupDatabase(writer)
+				writer.Close()
+			}, func() {
+				if backupErr != nil {
+					dialog.ShowError(errors.New(T("backup.create_error", backupErr)), w)
+					return
+				}
+				recordAudit("backup", "Banco de Dados", 0, writer.URI().Name())
+				dialog.ShowInformation(T("common.success"), T("backup.saved", writer.URI().Path()), w)
+			})
+		}, w)
+		saveDlg.SetFileName(backupFileName())
+		saveDlg.Show()
+	})
+
+	restoreBtn := newButton(T("backup.restore"), func() {
+		dialog.ShowFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			if reader == nil {
+				return
+			}
+			msg := T("backup.restore_confirm", reader.URI().Name())
+			dialog.ShowConfirm(T("backup.restore"), msg, func(confirm bool) {
+				if !confirm {
+					reader.Close()
+					return
+				}
+				var staged string
+				var restoreErr error
+				runWithProgress(w, T("backup.restoring"), func() {
+					staged, restoreErr = restoreDatabase(reader)
+					reader.Close()
+				}, func() {
+					if restoreErr == nil {
+						restoreErr = finishRestore(staged)
+					}
+					if restoreErr != nil {
+						dialog.ShowError(errors.New(T("backup.restore_error", restoreErr)), w)
+						return
+					}
+					invalidateProductCache()
+					invalidateStoreCache()
+					recordAudit("restaurar backup", "Banco de Dados", 0, reader.URI().Name())
+					dialog.ShowInformation(T("common.success"), T("backup.restored"), w)
+					logout(w)
+				})
+			}, w)
+		}, w)
+	})
+
+	exportJSONBtn := newButton(T("backup.export_json"), func() {
+		if !connectionAvailable(w) {
+			return
+		}
+		var data exportData
+		saveDlg := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			if writer == nil {
+				return
+			}
+			defer writer.Close()
+			if err := writeExportJSON(writer, data); err != nil {
+				dialog.ShowError(errors.New(T("backup.export_json_error", err)), w)
+				return
+			}
+			dialog.ShowInformation(T("common.success"), T("backup.exported",
+				len(data.Products), len(data.Stores), len(data.Groups), len(data.Quotes), len(data.Prescriptions)), w)
+		}, w)
+		saveDlg.SetFileName(fmt.Sprintf("dados_cotacao_%s.json", time.Now().Format("20060102_150405")))
+		runWithProgress(w, T("backup.collecting"), func() {
+			data = collectExportData()
+		}, saveDlg.Show)
+	})
+
+	importJSONBtn := newButton(T("backup.import_json"), func() {
+		dialog.ShowFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			if reader == nil {
+				return
+			}
+			defer reader.Close()
+			data, err := readExportJSON(reader)
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			var summary importSummary
+			var importErr error
+			runWithProgress(w, T("backup.importing"), func() {
+				summary, importErr = importJSONData(data)
+			}, func() {
+				if importErr != nil {
+					dialog.ShowError(errors.New(T("backup.import_cancelled", friendlyDBError(importErr))), w)
+					return
+				}
+				invalidateProductCache()
+				invalidateStoreCache()
+				recordAudit("importar JSON", "Banco de Dados", 0, reader.URI().Name())
+				dialog.ShowInformation(T("common.import_done"), summary.String(), w)
+			})
+		}, w)
+	})
+
+	info := widget.NewLabel(T("backup.current_database", dbDriver))
+	writeActions(restoreBtn, importJSONBtn)
+	return container.NewVBox(info, backupBtn, restoreBtn, widget.NewSeparator(), exportJSONBtn, importJSONBtn)
+}
@@ -0,0 +1,45 @@
+package main
+
+import "sync"
+
+type optionsCache struct {
+	mu      sync.Mutex
+	valid   bool
+	options []string
+	ids     map[string]uint
+}
+
+var (
+	productCache optionsCache
+	storeCache   optionsCache
+)
+
+func (c *optionsCache) get(load func() ([]string, map[string]uint)) ([]string, map[string]uint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.valid {
+		c.options, c.ids = load()
+		c.valid = true
+	}
+	options := append([]string(nil), c.options...)
+	ids := make(map[string]uint, len(c.ids))
+	for k, v := range c.ids {
+		ids[k] = v
+	}
+	return options, ids
+}
+
+func (c *optionsCache) invalidate() {
+	c.mu.Lock()
+	c.valid = false
+	c.options, c.ids = nil, nil
+	c.mu.Unlock()
+}
+
+func invalidateProductCache() {
+	productCache.invalidate()
+}
+
+func invalidateStoreCache() {
+	storeCache.invalidate()
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+)
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(*b)
+}
+
+func sameInt(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func sameString(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func productChanged(before, after Product) bool {
+	return before.Name != after.Name ||
+		before.StandardUnit != after.StandardUnit ||
+		before.Category != after.Category ||
+		before.ImagePath != after.ImagePath ||
+		before.Density != after.Density
+}
+
+func storeChanged(before, after Store) bool {
+	return before.Name != after.Name ||
+		before.Endereco != after.Endereco ||
+		!sameString(before.CNPJ, after.CNPJ) ||
+		before.Representative != after.Representative ||
+		before.Preferred != after.Preferred
+}
+
+func quoteChanged(before, after Quote) bool {
+	return before.ProductID != after.ProductID ||
+		before.StoreID != after.StoreID ||
+		before.Price != after.Price ||
+		before.Currency != after.Currency ||
+		before.PackagingSize != after.PackagingSize ||
+		before.PackagingUnit != after.PackagingUnit ||
+		before.ConversionFactor != after.ConversionFactor ||
+		!before.Date.Equal(after.Date) ||
+		before.Notes != after.Notes ||
+		before.MinOrderQuantity != after.MinOrderQuantity ||
+		before.ShippingCost != after.ShippingCost ||
+		before.ShippingPerUnit != after.ShippingPerUnit ||
+		!sameTime(before.ValidUntil, after.ValidUntil) ||
+		!sameInt(before.DeliveryDays, after.DeliveryDays)
+}
+
+func prescriptionChanged(before, after Prescription) bool {
+	return before.GroupID != after.GroupID ||
+		before.ProductID != after.ProductID ||
+		before.RequiredQuantity != after.RequiredQuantity ||
+		before.RequiredUnit != after.RequiredUnit ||
+		!sameTime(before.SeasonStart, after.SeasonStart) ||
+		!sameTime(before.SeasonEnd, after.SeasonEnd)
+}
+
+func showNoChanges(w fyne.Window) {
+	dialog.ShowInformation(T("changes.none_title"), T("changes.none"), w)
+}
+
+func confirmEach(w fyne.Window, warnings []string, save func()) {
+	if len(warnings) == 0 {
+		save()
+		return
+	}
+	confirmSave(w, warnings[0], func() { confirmEach(w, warnings[1:], save) })
+}
+
+func confirmSave(w fyne.Window, warning string, save func()) {
+	if warning == "" {
+		save()
+		return
+	}
+	dialog.ShowConfirm(T("changes.confirm_title"), warning, func(confirm bool) {
+		if confirm {
+			save()
+		}
+	}, w)
+}
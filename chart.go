@@ -0,0 +1,176 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+)
+
+const (
+	chartWidth  = 760
+	chartHeight = 380
+	chartLeft   = 80
+	chartRight  = 170
+	chartTop    = 20
+	chartBottom = 40
+)
+
+var chartColors = []color.Color{
+	color.NRGBA{R: 0x1f, G: 0x77, B: 0xb4, A: 0xff},
+	color.NRGBA{R: 0xff, G: 0x7f, B: 0x0e, A: 0xff},
+	color.NRGBA{R: 0x2c, G: 0xa0, B: 0x2c, A: 0xff},
+	color.NRGBA{R: 0xd6, G: 0x27, B: 0x28, A: 0xff},
+	color.NRGBA{R: 0x94, G: 0x67, B: 0xbd, A: 0xff},
+	color.NRGBA{R: 0x8c, G: 0x56, B: 0x4b, A: 0xff},
+	color.NRGBA{R: 0xe3, G: 0x77, B: 0xc2, A: 0xff},
+	color.NRGBA{R: 0x7f, G: 0x7f, B: 0x7f, A: 0xff},
+}
+
+type chartPoint struct {
+	date  time.Time
+	price float64
+}
+
+type chartSeries struct {
+	storeName string
+	points    []chartPoint
+}
+
+func priceSeries(productID uint, start, end time.Time) []chartSeries {
+	var quotes []Quote
+	db.Preload("Store").Where("product_id = ? AND date BETWEEN ? AND ?", productID, start, end).Order("date").Find(&quotes)
+
+	var series []chartSeries
+	index := make(map[uint]int)
+	for _, q := range quotes {
+		ppu, ok := pricePerStandardUnit(q)
+		if !ok {
+			continue
+		}
+		i, found := index[q.StoreID]
+		if !found {
+			i = len(series)
+			index[q.StoreID] = i
+			series = append(series, chartSeries{storeName: q.Store.Name})
+		}
+		series[i].points = append(series[i].points, chartPoint{date: q.Date, price: ppu})
+	}
+	return series
+}
+
+func buildPriceChart(series []chartSeries, unit string) fyne.CanvasObject {
+	plotW := float32(chartWidth - chartLeft - chartRight)
+	plotH := float32(chartHeight - chartTop - chartBottom)
+
+	minDate, maxDate := series[0].points[0].date, series[0].points[0].date
+	minPrice, maxPrice := series[0].points[0].price, series[0].points[0].price
+	for _, s := range series {
+		for _, p := range s.points {
+			if p.date.Before(minDate) {
+				minDate = p.date
+			}
+			if p.date.After(maxDate) {
+				maxDate = p.date
+			}
+			if p.price < minPrice {
+				minPrice = p.price
+			}
+			if p.price > maxPrice {
+				maxPrice = p.price
+			}
+		}
+	}
+	if minPrice == maxPrice {
+		minPrice, maxPrice = minPrice*0.9, maxPrice*1.1
+		if minPrice == maxPrice {
+			maxPrice = minPrice + 1
+		}
+	}
+
+	xFor := func(d time.Time) float32 {
+		span := maxDate.Sub(minDate)
+		if span == 0 {
+			return chartLeft + plotW/2
+		}
+		return chartLeft + plotW*float32(d.Sub(minDate))/float32(span)
+	}
+	yFor := func(price float64) float32 {
+		return chartTop + plotH - plotH*float32((price-minPrice)/(maxPrice-minPrice))
+	}
+
+	fg := theme.Color(theme.ColorNameForeground)
+	var objs []fyne.CanvasObject
+
+	xAxis := canvas.NewLine(fg)
+	xAxis.Position1 = fyne.NewPos(chartLeft, chartTop+plotH)
+	xAxis.Position2 = fyne.NewPos(chartLeft+plotW, chartTop+plotH)
+	yAxis := canvas.NewLine(fg)
+	yAxis.Position1 = fyne.NewPos(chartLeft, chartTop)
+	yAxis.Position2 = fyne.NewPos(chartLeft, chartTop+plotH)
+	objs = append(objs, xAxis, yAxis)
+
+	for i := 0; i <= 4; i++ {
+		price := minPrice + (maxPrice-minPrice)*float64(i)/4
+		y := yFor(price)
+		label := canvas.NewText(fmt.Sprintf("R$ %.2f", price), fg)
+		label.TextSize = 10
+		label.Move(fyne.NewPos(4, y-7))
+		tick := canvas.NewLine(fg)
+		tick.Position1 = fyne.NewPos(chartLeft-4, y)
+		tick.Position2 = fyne.NewPos(chartLeft, y)
+		objs = append(objs, label, tick)
+	}
+
+	startLabel := canvas.NewText(minDate.Format("2006-01-02"), fg)
+	startLabel.TextSize = 10
+	startLabel.Move(fyne.NewPos(chartLeft-30, chartTop+plotH+8))
+	objs = append(objs, startLabel)
+	if !maxDate.Equal(minDate) {
+		endLabel := canvas.NewText(maxDate.Format("2006-01-02"), fg)
+		endLabel.TextSize = 10
+		endLabel.Move(fyne.NewPos(chartLeft+plotW-30, chartTop+plotH+8))
+		objs = append(objs, endLabel)
+	}
+
+	unitLabel := canvas.NewText("R$/"+unit, fg)
+	unitLabel.TextSize = 10
+	unitLabel.TextStyle = fyne.TextStyle{Bold: true}
+	unitLabel.Move(fyne.NewPos(4, 0))
+	objs = append(objs, unitLabel)
+
+	for i, s := range series {
+		c := chartColors[i%len(chartColors)]
+		for j, p := range s.points {
+			x, y := xFor(p.date), yFor(p.price)
+			if j > 0 {
+				prev := s.points[j-1]
+				line := canvas.NewLine(c)
+				line.StrokeWidth = 2
+				line.Position1 = fyne.NewPos(xFor(prev.date), yFor(prev.price))
+				line.Position2 = fyne.NewPos(x, y)
+				objs = append(objs, line)
+			}
+			dot := canvas.NewCircle(c)
+			dot.Resize(fyne.NewSize(6, 6))
+			dot.Move(fyne.NewPos(x-3, y-3))
+			objs = append(objs, dot)
+		}
+
+		legendY := float32(chartTop + i*18)
+		swatch := canvas.NewRectangle(c)
+		swatch.Resize(fyne.NewSize(12, 12))
+		swatch.Move(fyne.NewPos(chartLeft+plotW+16, legendY))
+		name := canvas.NewText(s.storeName, fg)
+		name.TextSize = 11
+		name.Move(fyne.NewPos(chartLeft+plotW+32, legendY-2))
+		objs = append(objs, swatch, name)
+	}
+
+	chart := container.NewWithoutLayout(objs...)
+	return container.NewGridWrap(fyne.NewSize(chartWidth, chartHeight), chart)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Registros filhos (contatos de uma loja, faixas de uma cotação) editados num
+// diálogo com formulário de inclusão, lista e remoção do item selecionado.
+type childRecords[R any] struct {
+	title         string
+	intro         fyne.CanvasObject
+	form          *widget.Form
+	addLabel      string
+	removeLabel   string
+	listLabel     string
+	noSelection   string
+	confirmRemove string
+	auditEntity   string
+	load          func() []R
+	format        func(R) string
+	id            func(R) uint
+	auditDetail   func(R) string
+	build         func(current []R) (R, error)
+	clearForm     func()
+}
+
+func showChildRecords[R any](w fyne.Window, spec childRecords[R], onChanged func()) {
+	records := spec.load()
+	selected := -1
+	list := widget.NewList(
+		func() int { return len(records) },
+		func() fyne.CanvasObject { return widget.NewLabel("template") },
+		func(id widget.ListItemID, co fyne.CanvasObject) {
+			co.(*widget.Label).SetText(spec.format(records[id]))
+		},
+	)
+	list.OnSelected = func(id widget.ListItemID) { selected = id }
+	reload := func() {
+		records = spec.load()
+		selected = -1
+		list.UnselectAll()
+		list.Refresh()
+		onChanged()
+	}
+
+	addBtn := newButton(spec.addLabel, func() {
+		record, err := spec.build(records)
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		if err := db.Create(&record).Error; err != nil {
+			showDBError(err, w)
+			return
+		}
+		recordAudit(auditCreate, spec.auditEntity, spec.id(record), spec.auditDetail(record))
+		spec.clearForm()
+		reload()
+	})
+
+	removeBtn := newButton(spec.removeLabel, func() {
+		if selected < 0 || selected >= len(records) {
+			dialog.ShowError(errors.New(spec.noSelection), w)
+			return
+		}
+		record := records[selected]
+		dialog.ShowConfirm(T("common.confirmation"), fmt.Sprintf(spec.confirmRemove, spec.format(record)), func(confirm bool) {
+			if !confirm {
+				return
+			}
+			if err := db.Delete(&record).Error; err != nil {
+				showDBError(err, w)
+				return
+			}
+			recordAudit(auditDelete, spec.auditEntity, spec.id(record), spec.auditDetail(record))
+			reload()
+		}, w)
+	})
+
+	writeActions(addBtn, removeBtn)
+	top := container.NewVBox(spec.form, container.NewHBox(addBtn, removeBtn), widget.NewLabel(spec.listLabel))
+	if spec.intro != nil {
+		top.Objects = append([]fyne.CanvasObject{spec.intro}, top.Objects...)
+	}
+	content := container.NewBorder(top, nil, nil, nil, list)
+	dlg := dialog.NewCustom(spec.title, T("common.close"), content, w)
+	dlg.Resize(fyne.NewSize(520, 460))
+	dlg.Show()
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"errors"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+func copyReportButton(w fyne.Window, text func() string) *widget.Button {
+	return newButton(T("clipboard.copy"), func() {
+		report := strings.TrimSpace(text())
+		if report == "" || report == T("report.generating") {
+			dialog.ShowError(errors.New(T("clipboard.empty")), w)
+			return
+		}
+		fyne.CurrentApp().Clipboard().SetContent(report)
+		dialog.ShowInformation(T("clipboard.copied_title"), T("clipboard.copied"), w)
+	})
+}
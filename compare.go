@@ -0,0 +1,120 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+type comparedQuote struct {
+	quote Quote
+	ppu   float64
+	ok    bool
+}
+
+func loadComparedQuotes(ids []uint) []comparedQuote {
+	var quotes []Quote
+	db.Preload("Product").Preload("Store").Where("id IN ?", ids).Find(&quotes)
+	var rows []comparedQuote
+	for _, q := range quotes {
+		ppu, ok := pricePerStandardUnit(q)
+		rows = append(rows, comparedQuote{quote: q, ppu: ppu, ok: ok})
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].ok != rows[j].ok {
+			return rows[i].ok
+		}
+		return rows[i].ppu < rows[j].ppu
+	})
+	return rows
+}
+
+func showQuoteComparison(w fyne.Window, ids []uint) {
+	if len(ids) < 2 {
+		dialog.ShowError(errors.New(T("compare.select_two")), w)
+		return
+	}
+	rows := loadComparedQuotes(ids)
+	if len(rows) < 2 {
+		dialog.ShowError(errors.New(T("compare.not_found")), w)
+		return
+	}
+
+	base := rows[0]
+	units := make(map[string]bool)
+	for _, r := range rows {
+		units[r.quote.Product.StandardUnit] = true
+	}
+
+	headers := []string{T("compare.product"), T("compare.store"), T("compare.date"), T("compare.price"), T("compare.unit_price"), T("compare.difference")}
+	table := widget.NewTable(
+		func() (int, int) {
+			return len(rows) + 1, len(headers)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("template template")
+		},
+		func(id widget.TableCellID, co fyne.CanvasObject) {
+			label := co.(*widget.Label)
+			label.Importance = widget.MediumImportance
+			label.TextStyle = fyne.TextStyle{}
+			if id.Row == 0 {
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				label.SetText(headers[id.Col])
+				return
+			}
+			r := rows[id.Row-1]
+			if id.Row == 1 && r.ok {
+				label.Importance = widget.SuccessImportance
+			}
+			switch id.Col {
+			case 0:
+				label.SetText(r.quote.Product.Name)
+			case 1:
+				label.SetText(r.quote.Store.Name)
+			case 2:
+				label.SetText(r.quote.Date.Format("2006-01-02"))
+			case 3:
+				label.SetText(formatQuotePrice(r.quote))
+			case 4:
+				if r.ok {
+					label.SetText(fmt.Sprintf("R$ %.4f / %s", r.ppu, r.quote.Product.StandardUnit))
+				} else {
+					label.SetText("N/A")
+				}
+			case 5:
+				switch {
+				case !r.ok || !base.ok || base.ppu == 0:
+					label.SetText("N/A")
+				case id.Row == 1:
+					label.SetText(T("compare.lowest"))
+				default:
+					diff := (r.ppu - base.ppu) / base.ppu * 100
+					label.SetText(fmt.Sprintf("+R$ %.4f (+%.1f%%)", r.ppu-base.ppu, diff))
+				}
+			}
+		},
+	)
+	table.SetColumnWidth(0, 180)
+	table.SetColumnWidth(1, 180)
+	table.SetColumnWidth(2, 100)
+	table.SetColumnWidth(3, 170)
+	table.SetColumnWidth(4, 190)
+	table.SetColumnWidth(5, 190)
+
+	var top fyne.CanvasObject = widget.NewLabel(T("compare.summary", len(rows)))
+	if len(units) > 1 {
+		warning := widget.NewLabel(T("compare.mixed_units"))
+		warning.Importance = widget.WarningImportance
+		top = container.NewVBox(top, warning)
+	}
+	content := container.NewBorder(top, nil, nil, nil, table)
+	dlg := dialog.NewCustom(T("compare.title"), T("common.close"), content, w)
+	dlg.Resize(fyne.NewSize(1050, 400))
+	dlg.Show()
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+	"gorm.io/gorm"
+)
+
+const contactPrincipal = "Principal"
+
+var contactTypes = []string{contactPrincipal, "Vendedor", "Financeiro", "WhatsApp", "Outro"}
+
+type StoreContact struct {
+	gorm.Model
+	StoreID uint   `gorm:"not null;index"`
+	Type    string `gorm:"not null;default:Principal"`
+	Name    string
+	Phone   string `gorm:"not null"`
+}
+
+func primaryContact(contacts []StoreContact) (StoreContact, bool) {
+	for _, c := range contacts {
+		if c.Type == contactPrincipal {
+			return c, true
+		}
+	}
+	if len(contacts) > 0 {
+		return contacts[0], true
+	}
+	return StoreContact{}, false
+}
+
+func primaryPhone(s Store) string {
+	if c, ok := primaryContact(s.Contacts); ok {
+		return displayPhone(c.Phone)
+	}
+	return ""
+}
+
+func formatContact(c StoreContact) string {
+	if c.Name == "" {
+		return fmt.Sprintf("%s: %s", c.Type, displayPhone(c.Phone))
+	}
+	return fmt.Sprintf("%s: %s - %s", c.Type, c.Name, displayPhone(c.Phone))
+}
+
+func describeStoreContact(s Store, indent string) string {
+	var parts []string
+	if s.Representative != "" {
+		parts = append(parts, T("contact.report_representative", s.Representative))
+	}
+	if phone := primaryPhone(s); phone != "" {
+		parts = append(parts, phone)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return T("contact.report_line", indent, strings.Join(parts, " - "))
+}
+
+func storesWithContacts(storeIDs []uint) map[uint]bool {
+	found := make(map[uint]bool)
+	if len(storeIDs) == 0 {
+		return found
+	}
+	var ids []uint
+	db.Model(&StoreContact{}).Where("store_id IN ?", storeIDs).Distinct().Pluck("store_id", &ids)
+	for _, id := range ids {
+		found[id] = true
+	}
+	return found
+}
+
+func migrateStorePhones() {
+	m := db.Migrator()
+	if !m.HasColumn("stores", "telefone") {
+		return
+	}
+	type legacyPhone struct {
+		ID       uint
+		Telefone string
+	}
+	var phones []legacyPhone
+	db.Table("stores").Select("id, telefone").Where("telefone IS NOT NULL AND telefone <> ''").Scan(&phones)
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, p := range phones {
+			contact := StoreContact{StoreID: p.ID, Type: contactPrincipal, Phone: p.Telefone}
+			if err := tx.Create(&contact).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Table("stores").Where("telefone IS NOT NULL AND telefone <> ''").Update("telefone", "").Error
+	})
+	if err != nil {
+		slog.Error("Erro ao migrar telefones das lojas", "erro", err)
+		return
+	}
+	if len(phones) > 0 {
+		slog.Info("Telefones de loja migrados para contatos", "quantidade", len(phones))
+	}
+	if err := m.DropColumn("stores", "telefone"); err != nil {
+		slog.Error("Erro ao remover coluna telefone de lojas", "erro", err)
+	}
+}
+
+func showStoreContacts(w fyne.Window, store Store, onChanged func()) {
+	typeSelect := widget.NewSelect(contactTypes, nil)
+	typeSelect.SetSelected(contactPrincipal)
+	nameEntry := newEntry()
+	nameEntry.SetPlaceHolder(T("contacts.name_placeholder"))
+	phoneEntry := newEntry()
+	applyPhoneMask(phoneEntry)
+
+	showChildRecords(w, childRecords[StoreContact]{
+		title: T("contacts.title", store.Name),
+		form: widget.NewForm(
+			widget.NewFormItem(T("contacts.type"), typeSelect),
+			widget.NewFormItem(T("contacts.name"), nameEntry),
+			widget.NewFormItem(T("contacts.phone"), phoneEntry),
+		),
+		addLabel:      T("contacts.add"),
+		removeLabel:   T("contacts.remove"),
+		listLabel:     T("contacts.list"),
+		noSelection:   T("contacts.no_selection"),
+		confirmRemove: T("contacts.confirm_remove"),
+		auditEntity:   "Contato de Loja",
+		load: func() []StoreContact {
+			var contacts []StoreContact
+			db.Where("store_id = ?", store.ID).Order("id").Find(&contacts)
+			return contacts
+		},
+		format: formatContact,
+		id:     func(c StoreContact) uint { return c.ID },
+		auditDetail: func(c StoreContact) string {
+			return fmt.Sprintf("%s - %s", store.Name, formatContact(c))
+		},
+		build: func([]StoreContact) (StoreContact, error) {
+			phone, err := validatePhone(phoneEntry.Text)
+			if err != nil {
+				return StoreContact{}, err
+			}
+			if phone == "" {
+				return StoreContact{}, errors.New(T("contacts.phone_required"))
+			}
+			contact := StoreContact{StoreID: store.ID, Type: typeSelect.Selected, Name: nameEntry.Text, Phone: phone}
+			if contact.Type == "" {
+				contact.Type = contactPrincipal
+			}
+			return contact, nil
+		},
+		clearForm: func() {
+			nameEntry.SetText("")
+			phoneEntry.SetText("")
+		},
+	}, onChanged)
+}
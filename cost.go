@@ -0,0 +1,44 @@
+package main
+
+import (
+	"math"
+	"sort"
+)
+
+// Cálculo de custo sem acesso a banco, rede ou UI. Os valores ficam na moeda
+// da cotação; a conversão para BRL é feita em quoteTotalCost.
+
+func computeProductCost(quote Quote, requiredQty float64) float64 {
+	divisor := quote.PackagingSize * quote.ConversionFactor
+	if divisor == 0 {
+		return math.Inf(1)
+	}
+	price := quote.Price
+	if tier, ok := applicableTier(quote, requiredQty); ok {
+		price = tier.Price
+	}
+	return price / divisor * requiredQty
+}
+
+func computeShipping(quote Quote, requiredQty float64) float64 {
+	if quote.ShippingPerUnit {
+		return quote.ShippingCost * requiredQty
+	}
+	return quote.ShippingCost
+}
+
+// Retorna +Inf quando tamanho da embalagem ou fator de conversão é zero, de
+// modo que a cotação nunca vence.
+func computeTotalCost(quote Quote, requiredQty float64) float64 {
+	return computeProductCost(quote, requiredQty) + computeShipping(quote, requiredQty)
+}
+
+func sortByCost(costs []quoteCost, withContact map[uint]bool) {
+	sort.SliceStable(costs, func(i, j int) bool {
+		ci, cj := costCents(costs[i].cost), costCents(costs[j].cost)
+		if ci != cj {
+			return ci < cj
+		}
+		return winsTie(costs[i].quote, costs[j].quote, withContact)
+	})
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+)
+
+func saveCSV(w fyne.Window, fileName string, rows [][]string) {
+	saveDlg := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		if writer == nil {
+			return
+		}
+		defer writer.Close()
+		cw := csv.NewWriter(writer)
+		if err := cw.WriteAll(rows); err != nil {
+			dialog.ShowError(errors.New(T("csv.export_error", err)), w)
+			return
+		}
+		dialog.ShowInformation(T("common.success"), T("csv.exported"), w)
+	}, w)
+	saveDlg.SetFileName(fileName)
+	saveDlg.Show()
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func productCSVRows() [][]string {
+	var products []Product
+	db.Find(&products)
+	rows := [][]string{{"ID", "Nome", "Unidade Padrão", "Categoria", "Densidade (kg/L)"}}
+	for _, p := range products {
+		rows = append(rows, []string{strconv.Itoa(int(p.ID)), p.Name, p.StandardUnit, p.Category, formatDensity(p)})
+	}
+	return rows
+}
+
+func storeCSVRows() [][]string {
+	var stores []Store
+	db.Preload("Contacts").Find(&stores)
+	rows := [][]string{{"ID", "Nome", "Endereço", "Telefone Principal", "CNPJ", "Representante", "Preferencial", "Contatos"}}
+	for _, s := range stores {
+		var contacts []string
+		for _, c := range s.Contacts {
+			contacts = append(contacts, formatContact(c))
+		}
+		rows = append(rows, []string{strconv.Itoa(int(s.ID)), s.Name, s.Endereco, primaryPhone(s), displayCNPJ(s.CNPJ), s.Representative, yesNo(s.Preferred), strings.Join(contacts, "; ")})
+	}
+	return rows
+}
+
+func quoteCSVRows() [][]string {
+	var quotes []Quote
+	db.Preload("Product").Preload("Store").Find(&quotes)
+	rows := [][]string{{"ID", "Produto", "Loja", "Preço", "Moeda", "Tamanho Embalagem", "Unidade Embalagem", "Fator Conversão", "Frete", "Tipo Frete", "Prazo de Entrega (dias)", "Data", "Válida Até", "Observações"}}
+	for _, q := range quotes {
+		validUntil := ""
+		if q.ValidUntil != nil {
+			validUntil = q.ValidUntil.Format("2006-01-02")
+		}
+		rows = append(rows, []string{
+			strconv.Itoa(int(q.ID)),
+			q.Product.Name,
+			q.Store.Name,
+			formatFloat(q.Price),
+			q.Currency,
+			formatFloat(q.PackagingSize),
+			q.PackagingUnit,
+			formatFloat(q.ConversionFactor),
+			formatFloat(q.ShippingCost),
+			T(shippingMode(q)),
+			deliveryDaysText(q),
+			q.Date.Format("2006-01-02"),
+			validUntil,
+			q.Notes,
+		})
+	}
+	return rows
+}
+
+func reportCSVRows(groupID uint, category string, start, end time.Time, opts reportOptions) ([][]string, error) {
+	prescriptions := loadReportPrescriptions(groupID, category, start, end)
+
+	rows := [][]string{{"Categoria", "Produto", "Quantidade Requerida", "Unidade", "Status", "Loja", "Endereço", "Representante", "Custo Total", "Custo Produto", "Frete", "Preço", "Moeda", "Tamanho Embalagem", "Unidade Embalagem", "Fator Conversão", "Prazo de Entrega (dias)", "Data", "Observações"}}
+	for _, pres := range prescriptions {
+		if pres.Product.ID == 0 {
+			continue
+		}
+		requiredQty, err := requiredStandardQuantity(pres)
+		if err != nil {
+			continue
+		}
+
+		quotes, err := quoteRepo.FindByDate(pres.ProductID, start, end)
+		if err != nil {
+			return nil, err
+		}
+		quotes, _ = splitExpiredQuotes(quotes, end)
+		quotes, _ = filterByDelivery(quotes, opts.deliveryLimit)
+		costs, _ := rankQuotes(quotes, requiredQty, opts.tolerance)
+		for idx, qc := range costs {
+			status := "Perdedor"
+			if idx == 0 {
+				status = "Vencedor"
+			}
+			rows = append(rows, []string{
+				pres.Product.Category,
+				pres.Product.Name,
+				formatFloat(pres.RequiredQuantity),
+				pres.RequiredUnit,
+				status,
+				qc.quote.Store.Name,
+				qc.quote.Store.Endereco,
+				qc.quote.Store.Representative,
+				strconv.FormatFloat(qc.cost, 'f', 2, 64),
+				strconv.FormatFloat(qc.productCost, 'f', 2, 64),
+				strconv.FormatFloat(qc.shipping, 'f', 2, 64),
+				formatFloat(qc.quote.Price),
+				qc.quote.Currency,
+				formatFloat(qc.quote.PackagingSize),
+				qc.quote.PackagingUnit,
+				formatFloat(qc.quote.ConversionFactor),
+				deliveryDaysText(qc.quote),
+				qc.quote.Date.Format("2006-01-02"),
+				qc.quote.Notes,
+			})
+		}
+	}
+	return rows, nil
+}
+
+func importProductsCSV(r io.Reader) (int, []string, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	records, err := cr.ReadAll()
+	if err != nil {
+		return 0, nil, errors.New(T("csv.read_error", err))
+	}
+
+	imported := 0
+	var rejected []string
+	for i, record := range records {
+		line := i + 1
+		if i == 0 && len(record) > 0 && strings.EqualFold(strings.TrimSpace(record[0]), "Nome") {
+			continue
+		}
+		if len(record) < 2 {
+			rejected = append(rejected, T("csv.missing_columns", line))
+			continue
+		}
+		name := strings.TrimSpace(record[0])
+		unit := normalizeUnit(record[1])
+		if name == "" {
+			rejected = append(rejected, T("csv.empty_name", line))
+			continue
+		}
+		if unit == "" {
+			rejected = append(rejected, T("csv.empty_unit", line, name))
+			continue
+		}
+		var existing Product
+		if err := db.Where("name = ?", name).First(&existing).Error; err == nil {
+			rejected = append(rejected, T("csv.duplicate", line, name))
+			continue
+		}
+		category := defaultCategory
+		if len(record) > 2 && strings.TrimSpace(record[2]) != "" {
+			category = strings.TrimSpace(record[2])
+		}
+		product := Product{Name: name, StandardUnit: unit, Category: category}
+		if err := db.Create(&product).Error; err != nil {
+			rejected = append(rejected, T("csv.line_error", line, err))
+			continue
+		}
+		recordAudit(auditCreate, "Produto", product.ID, product.Name+" (importação CSV)")
+		invalidateProductCache()
+		imported++
+	}
+	return imported, rejected, nil
+}
+
+func yesNo(v bool) string {
+	if v {
+		return "Sim"
+	}
+	return "Não"
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCurrency           = "BRL"
+	exchangeRateAPI           = "https://economia.awesomeapi.com.br/json/last/%s-%s"
+	exchangeRateTimeout       = 5 * time.Second
+	exchangeRateRetryInterval = time.Minute
+	exchangeRateCacheTTL      = 30 * time.Minute
+)
+
+var currencyOptions = []string{"BRL", "USD"}
+
+type cachedRate struct {
+	rate      float64
+	fetchedAt time.Time
+}
+
+var (
+	rateCacheMu  sync.Mutex
+	rateCache    = make(map[string]cachedRate)
+	rateFailures = make(map[string]time.Time)
+)
+
+func rateKey(from, to string) string {
+	return strings.ToUpper(strings.TrimSpace(from)) + "-" + strings.ToUpper(strings.TrimSpace(to))
+}
+
+// Lido pelas telas e pelos cálculos de custo: nunca acessa a rede, apenas o
+// que fetchExchangeRate deixou em cache.
+func cachedExchangeRate(from, to string) (float64, bool) {
+	rateCacheMu.Lock()
+	defer rateCacheMu.Unlock()
+	cached, ok := rateCache[rateKey(from, to)]
+	if !ok || time.Since(cached.fetchedAt) > exchangeRateCacheTTL {
+		return 0, false
+	}
+	return cached.rate, true
+}
+
+func storeExchangeRate(from, to string, rate float64) {
+	rateCacheMu.Lock()
+	defer rateCacheMu.Unlock()
+	rateCache[rateKey(from, to)] = cachedRate{rate: rate, fetchedAt: time.Now()}
+	delete(rateFailures, rateKey(from, to))
+}
+
+// Consulta a API só quando não há taxa em cache. Depois de uma falha espera
+// exchangeRateRetryInterval antes de tentar de novo, para que um relatório
+// gerado sem internet não pague o timeout a cada vez.
+func fetchExchangeRate(from, to string) (float64, error) {
+	if rate, ok := cachedExchangeRate(from, to); ok {
+		return rate, nil
+	}
+	key := rateKey(from, to)
+	rateCacheMu.Lock()
+	failedAt, failed := rateFailures[key]
+	rateCacheMu.Unlock()
+	if failed && time.Since(failedAt) < exchangeRateRetryInterval {
+		return 0, errors.New(T("currency.fetch_backoff", from, to))
+	}
+	rate, err := requestExchangeRate(from, to)
+	if err != nil {
+		rateCacheMu.Lock()
+		rateFailures[key] = time.Now()
+		rateCacheMu.Unlock()
+		return 0, err
+	}
+	storeExchangeRate(from, to, rate)
+	return rate, nil
+}
+
+// Chamado pelos relatórios, fora da thread da interface: busca o câmbio apenas
+// das moedas que aparecem nas cotações. Sem cotações em moeda estrangeira não
+// há nenhuma consulta.
+func prepareExchangeRates() {
+	var used []string
+	db.Model(&Quote{}).Where("currency <> '' AND currency <> ?", defaultCurrency).Distinct().Pluck("currency", &used)
+	for _, currency := range used {
+		if _, err := fetchExchangeRate(currency, defaultCurrency); err != nil {
+			slog.Warn("Câmbio automático indisponível, usando taxa manual", "moeda", currency, "erro", err)
+		}
+	}
+}
+
+func requestExchangeRate(from, to string) (float64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), exchangeRateTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(exchangeRateAPI, from, to), nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, errors.New(T("currency.fetch_failed", from, to, err))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, errors.New(T("currency.fetch_status", from, to, resp.StatusCode))
+	}
+
+	var payload map[string]struct {
+		Bid string `json:"bid"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return 0, errors.New(T("currency.invalid_response", err))
+	}
+	quote, ok := payload[from+to]
+	if !ok {
+		return 0, errors.New(T("currency.pair_not_found", from, to))
+	}
+	rate, err := strconv.ParseFloat(quote.Bid, 64)
+	if err != nil || rate <= 0 {
+		return 0, errors.New(T("currency.invalid_rate", quote.Bid))
+	}
+	return rate, nil
+}
+
+func exchangeRate(currency string) (float64, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" || currency == defaultCurrency {
+		return 1, nil
+	}
+	if rate, ok := cachedExchangeRate(currency, defaultCurrency); ok {
+		return rate, nil
+	}
+	return manualExchangeRate(currency)
+}
+
+func manualExchangeRate(currency string) (float64, error) {
+	key := currency + "_BRL_RATE"
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		return 0, errors.New(T("currency.rate_missing", currency, key))
+	}
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil || rate <= 0 {
+		return 0, errors.New(T("currency.rate_invalid", key, value))
+	}
+	return rate, nil
+}
+
+func priceInBRL(q Quote) (float64, error) {
+	rate, err := exchangeRate(q.Currency)
+	if err != nil {
+		return 0, err
+	}
+	return q.Price * rate, nil
+}
+
+func formatQuotePrice(q Quote) string {
+	if q.Currency == "" || q.Currency == defaultCurrency {
+		return fmt.Sprintf("R$ %.2f", q.Price)
+	}
+	converted, err := priceInBRL(q)
+	if err != nil {
+		return T("currency.no_rate", q.Currency, q.Price)
+	}
+	return fmt.Sprintf("%s %.2f (R$ %.2f)", q.Currency, q.Price, converted)
+}
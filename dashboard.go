@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+type dashboardStats struct {
+	products, stores, quotes, prescriptions int64
+	latest                                  Quote
+	hasLatest                               bool
+}
+
+func loadDashboardStats() dashboardStats {
+	var stats dashboardStats
+	db.Model(&Product{}).Count(&stats.products)
+	db.Model(&Store{}).Count(&stats.stores)
+	db.Model(&Quote{}).Count(&stats.quotes)
+	db.Model(&Prescription{}).Count(&stats.prescriptions)
+	stats.hasLatest = db.Preload("Product").Preload("Store").Order("created_at desc").First(&stats.latest).Error == nil
+	return stats
+}
+
+func dashboardTab() (fyne.CanvasObject, tabRefresh) {
+	productsLabel := widget.NewLabel("")
+	storesLabel := widget.NewLabel("")
+	quotesLabel := widget.NewLabel("")
+	prescriptionsLabel := widget.NewLabel("")
+	latestLabel := widget.NewLabel("")
+	latestLabel.Wrapping = fyne.TextWrapWord
+
+	show := func(stats dashboardStats) {
+		productsLabel.SetText(fmt.Sprintf("%d", stats.products))
+		storesLabel.SetText(fmt.Sprintf("%d", stats.stores))
+		quotesLabel.SetText(fmt.Sprintf("%d", stats.quotes))
+		prescriptionsLabel.SetText(fmt.Sprintf("%d", stats.prescriptions))
+
+		if !stats.hasLatest {
+			latestLabel.SetText(T("dashboard.no_quotes"))
+			return
+		}
+		latest := stats.latest
+		latestLabel.SetText(T("dashboard.latest_quote",
+			latest.Product.Name, latest.Store.Name, formatQuotePrice(latest), latest.PackagingSize, latest.PackagingUnit,
+			latest.Date.Format("2006-01-02"), latest.CreatedAt.Local().Format("2006-01-02 15:04")))
+	}
+	refresh := tabRefresh(func() (func(), func()) {
+		var stats dashboardStats
+		return func() { stats = loadDashboardStats() }, func() { show(stats) }
+	})
+	refresh.now()
+
+	title := widget.NewLabelWithStyle(T("dashboard.title"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	stats := widget.NewForm(
+		widget.NewFormItem(T("tab.products"), productsLabel),
+		widget.NewFormItem(T("tab.stores"), storesLabel),
+		widget.NewFormItem(T("tab.quotes"), quotesLabel),
+		widget.NewFormItem(T("tab.prescriptions"), prescriptionsLabel),
+	)
+	latestTitle := widget.NewLabelWithStyle(T("dashboard.latest_title"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	refreshBtn := newButton(T("common.refresh"), refresh.now)
+
+	return container.NewVBox(title, stats, widget.NewSeparator(), latestTitle, latestLabel, refreshBtn), refresh
+}
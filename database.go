@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"gorm.io/gorm"
+)
+
+const maxConnectAttempts = 5
+
+var dbDialector gorm.Dialector
+var dbDriver string
+
+func openWithRetry() (*gorm.DB, error) {
+	delay := 500 * time.Millisecond
+	var lastErr error
+	for attempt := 1; attempt <= maxConnectAttempts; attempt++ {
+		conn, err := gorm.Open(dbDialector, &gorm.Config{TranslateError: true})
+		if err == nil {
+			slog.Debug("Conexão ao banco de dados aberta", "driver", dbDriver, "tentativa", attempt)
+			return conn, nil
+		}
+		lastErr = err
+		slog.Warn("Falha na conexão ao banco de dados", "tentativa", attempt, "maximo", maxConnectAttempts, "driver", dbDriver, "erro", err)
+		if attempt < maxConnectAttempts {
+			time.Sleep(delay)
+			delay *= 2
+		}
+	}
+	return nil, lastErr
+}
+
+func ensureConnection() error {
+	if db != nil {
+		if sqlDB, err := db.DB(); err == nil && sqlDB.Ping() == nil {
+			return nil
+		}
+	}
+
+	conn, err := openWithRetry()
+	if err != nil {
+		slog.Error("Não foi possível reconectar ao banco de dados", "driver", dbDriver, "erro", err)
+		return errors.New(T("db.connect_error", maxConnectAttempts, err))
+	}
+	if db != nil {
+		if old, err := db.DB(); err == nil {
+			old.Close()
+		}
+	}
+	db = conn
+	slog.Info("Reconectado ao banco de dados.", "driver", dbDriver)
+	return nil
+}
+
+func connectionAvailable(w fyne.Window) bool {
+	if err := ensureConnection(); err != nil {
+		dialog.ShowError(err, w)
+		return false
+	}
+	return true
+}
+
+func dropUniqueConstraint(model interface{}, table, column string) {
+	m := db.Migrator()
+	for _, name := range []string{"uni_" + table + "_" + column, "idx_" + table + "_" + column, table + "_" + column + "_key"} {
+		if m.HasConstraint(model, name) {
+			if err := m.DropConstraint(model, name); err != nil {
+				slog.Warn("Erro ao remover restrição", "restricao", name, "erro", err)
+			}
+		}
+		if m.HasIndex(model, name) {
+			if err := m.DropIndex(model, name); err != nil {
+				slog.Warn("Erro ao remover índice", "indice", name, "erro", err)
+			}
+		}
+	}
+}
+
+func friendlyDBError(err error) string {
+	msg := strings.ToLower(err.Error())
+	switch {
+	case errors.Is(err, gorm.ErrDuplicatedKey),
+		strings.Contains(msg, "duplicate key"),
+		strings.Contains(msg, "unique constraint"):
+		return T("db.duplicate")
+	case errors.Is(err, gorm.ErrForeignKeyViolated),
+		strings.Contains(msg, "foreign key"):
+		return T("db.foreign_key")
+	case strings.Contains(msg, "not-null"),
+		strings.Contains(msg, "not null constraint"):
+		return T("db.not_null")
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return T("db.not_found")
+	}
+	return T("db.error", err)
+}
+
+func showDBError(err error, w fyne.Window) {
+	slog.Error("Erro no banco de dados", "erro", err)
+	dialog.ShowError(errors.New(friendlyDBError(err)), w)
+}
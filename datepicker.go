@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+)
+
+func monthName(m time.Month) string {
+	return T(fmt.Sprintf("date.month_%d", m))
+}
+
+func weekdayName(d time.Weekday) string {
+	return T(fmt.Sprintf("date.weekday_%d", d))
+}
+
+type DatePicker struct {
+	widget.Button
+	date      time.Time
+	hasDate   bool
+	OnChanged func(time.Time)
+}
+
+func NewDatePicker() *DatePicker {
+	d := &DatePicker{}
+	d.Text = T("date.select")
+	d.OnTapped = d.showCalendar
+	d.ExtendBaseWidget(d)
+	return d
+}
+
+func (d *DatePicker) Date() (time.Time, bool) {
+	return d.date, d.hasDate
+}
+
+func (d *DatePicker) SetDate(t time.Time) {
+	d.date = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	d.hasDate = true
+	d.SetText(d.date.Format("2006-01-02"))
+}
+
+func (d *DatePicker) Clear() {
+	d.date = time.Time{}
+	d.hasDate = false
+	d.SetText(T("date.select"))
+}
+
+func optionalDateField(d *DatePicker) fyne.CanvasObject {
+	clearBtn := newButton(T("common.clear"), d.Clear)
+	return container.NewBorder(nil, nil, nil, clearBtn, d)
+}
+
+func daysInMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func (d *DatePicker) showCalendar() {
+	c := fyne.CurrentApp().Driver().CanvasForObject(d)
+	if c == nil {
+		return
+	}
+
+	shown := d.date
+	if !d.hasDate {
+		shown = time.Now()
+	}
+	year, month := shown.Year(), shown.Month()
+
+	var popup *widget.PopUp
+	monthLabel := widget.NewLabel("")
+	grid := container.NewGridWithColumns(7)
+
+	var render func()
+	render = func() {
+		monthLabel.SetText(fmt.Sprintf("%s %d", monthName(month), year))
+		grid.Objects = nil
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			grid.Add(widget.NewLabel(weekdayName(day)))
+		}
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		for i := 0; i < int(first.Weekday()); i++ {
+			grid.Add(layout.NewSpacer())
+		}
+		for day := 1; day <= daysInMonth(year, month); day++ {
+			selected := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+			btn := newButton(strconv.Itoa(day), func() {
+				d.SetDate(selected)
+				popup.Hide()
+				if d.OnChanged != nil {
+					d.OnChanged(selected)
+				}
+			})
+			if d.hasDate && d.date.Equal(selected) {
+				btn.Importance = widget.HighImportance
+			}
+			grid.Add(btn)
+		}
+		grid.Refresh()
+	}
+
+	prevBtn := newButton("<", func() {
+		month--
+		if month < time.January {
+			month = time.December
+			year--
+		}
+		render()
+	})
+	nextBtn := newButton(">", func() {
+		month++
+		if month > time.December {
+			month = time.January
+			year++
+		}
+		render()
+	})
+	todayBtn := newButton(T("date.today"), func() {
+		now := time.Now()
+		year, month = now.Year(), now.Month()
+		render()
+	})
+	closeBtn := newButton(T("common.close"), func() {
+		popup.Hide()
+	})
+
+	header := container.NewBorder(nil, nil, prevBtn, nextBtn, container.NewCenter(monthLabel))
+	footer := container.NewHBox(todayBtn, layout.NewSpacer(), closeBtn)
+	render()
+
+	popup = widget.NewModalPopUp(container.NewVBox(header, grid, footer), c)
+	popup.Show()
+}
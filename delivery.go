@@ -0,0 +1,92 @@
+package main
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2/widget"
+)
+
+var reportDeliveryLimit *int
+
+// Os relatórios são gerados em segundo plano, então os valores dos campos da
+// aba são copiados na thread da interface antes de começar.
+type reportOptions struct {
+	deliveryLimit *int
+	tolerance     float64
+}
+
+func currentReportOptions() reportOptions {
+	opts := reportOptions{tolerance: preferredTolerance}
+	if reportDeliveryLimit != nil {
+		limit := *reportDeliveryLimit
+		opts.deliveryLimit = &limit
+	}
+	return opts
+}
+
+func parseDeliveryDays(text string) (*int, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, nil
+	}
+	days, err := strconv.Atoi(text)
+	if err != nil || days < 0 {
+		return nil, errors.New(T("delivery.invalid_days"))
+	}
+	return &days, nil
+}
+
+func formatDeliveryDays(q Quote) string {
+	if q.DeliveryDays == nil {
+		return T("delivery.unknown")
+	}
+	return T("delivery.days", *q.DeliveryDays)
+}
+
+func deliveryDaysText(q Quote) string {
+	if q.DeliveryDays == nil {
+		return ""
+	}
+	return strconv.Itoa(*q.DeliveryDays)
+}
+
+// Com limite definido, cotações com prazo desconhecido também ficam de fora,
+// pois não há como garantir a entrega a tempo.
+func filterByDelivery(quotes []Quote, limit *int) ([]Quote, []Quote) {
+	if limit == nil {
+		return quotes, nil
+	}
+	var within, late []Quote
+	for _, q := range quotes {
+		if q.DeliveryDays != nil && *q.DeliveryDays <= *limit {
+			within = append(within, q)
+		} else {
+			late = append(late, q)
+		}
+	}
+	return within, late
+}
+
+func describeLateQuote(q Quote, limit int) string {
+	return T("delivery.late_quote", q.ID, q.Store.Name, formatDeliveryDays(q), limit)
+}
+
+func deliveryLimitEntry() *widget.Entry {
+	entry := newEntry()
+	entry.SetPlaceHolder(T("delivery.limit_placeholder"))
+	if reportDeliveryLimit != nil {
+		entry.SetText(strconv.Itoa(*reportDeliveryLimit))
+	}
+	entry.Validator = func(text string) error {
+		_, err := parseDeliveryDays(text)
+		return err
+	}
+	entry.OnChanged = func(text string) {
+		if limit, err := parseDeliveryDays(text); err == nil {
+			reportDeliveryLimit = limit
+		}
+	}
+	return entry
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+const globalSearchLimit = 50
+
+func searchMatches(value, term string) bool {
+	return strings.Contains(foldText(value), foldText(strings.TrimSpace(term)))
+}
+
+// O LIKE não ignora acentos no banco, então as letras que podem vir acentuadas
+// viram curinga de um caractere e o filtro exato é feito por searchMatches.
+func likeCandidatePattern(term string) string {
+	var sb strings.Builder
+	sb.WriteString("%")
+	for _, r := range foldText(strings.TrimSpace(term)) {
+		switch {
+		case r == '%' || r == '_' || r == '\\':
+			sb.WriteRune('\\')
+			sb.WriteRune(r)
+		case strings.ContainsRune("aeioucn", r):
+			sb.WriteRune('_')
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteString("%")
+	return sb.String()
+}
+
+const likeClause = "LOWER(%s) LIKE ? ESCAPE '\\'"
+
+type globalResults struct {
+	products []Product
+	stores   []Store
+	quotes   []Quote
+}
+
+func (r globalResults) empty() bool {
+	return len(r.products) == 0 && len(r.stores) == 0 && len(r.quotes) == 0
+}
+
+func globalSearch(term string) globalResults {
+	pattern := likeCandidatePattern(term)
+	var results globalResults
+
+	var products []Product
+	db.Where(fmt.Sprintf(likeClause, "name"), pattern).Order("name").Find(&products)
+	for _, p := range products {
+		if len(results.products) < globalSearchLimit && searchMatches(p.Name, term) {
+			results.products = append(results.products, p)
+		}
+	}
+
+	var stores []Store
+	db.Where(fmt.Sprintf(likeClause, "name"), pattern).Order("name").Find(&stores)
+	for _, s := range stores {
+		if len(results.stores) < globalSearchLimit && searchMatches(s.Name, term) {
+			results.stores = append(results.stores, s)
+		}
+	}
+
+	var quotes []Quote
+	db.Preload("Product").Preload("Store").
+		Joins("JOIN products ON products.id = quotes.product_id AND products.deleted_at IS NULL").
+		Joins("JOIN stores ON stores.id = quotes.store_id AND stores.deleted_at IS NULL").
+		Where(fmt.Sprintf(likeClause+" OR "+likeClause, "products.name", "stores.name"), pattern, pattern).
+		Order("quotes.date desc, quotes.id").Find(&quotes)
+	for _, q := range quotes {
+		if len(results.quotes) < globalSearchLimit && (searchMatches(q.Product.Name, term) || searchMatches(q.Store.Name, term)) {
+			results.quotes = append(results.quotes, q)
+		}
+	}
+	return results
+}
+
+var recordFocusers = make(map[string]func(id uint))
+
+func registerRecordFocus(tabKey string, focus func(id uint)) {
+	recordFocusers[tabKey] = focus
+}
+
+func searchResultItems(lines []string, onTapped func(int)) fyne.CanvasObject {
+	box := container.NewVBox()
+	for i, line := range lines {
+		btn := newButton(line, func() { onTapped(i) })
+		btn.Alignment = widget.ButtonAlignLeading
+		btn.Importance = widget.LowImportance
+		box.Add(btn)
+	}
+	return box
+}
+
+func showGlobalSearch(w fyne.Window, term string, open func(tabKey string, id uint)) {
+	if strings.TrimSpace(term) == "" {
+		dialog.ShowError(errors.New(T("search.empty_term")), w)
+		return
+	}
+	var results globalResults
+	runWithProgress(w, T("search.searching"), func() {
+		results = globalSearch(term)
+	}, func() {
+		if results.empty() {
+			dialog.ShowInformation(T("search.title"), T("search.no_results", strings.TrimSpace(term)), w)
+			return
+		}
+		var dlg dialog.Dialog
+		choose := func(tabKey string, id uint) {
+			dlg.Hide()
+			open(tabKey, id)
+		}
+
+		var productLines, storeLines, quoteLines []string
+		for _, p := range results.products {
+			productLines = append(productLines, fmt.Sprintf("%s (%s) [%s]", p.Name, p.StandardUnit, categoryLabel(p.Category)))
+		}
+		for _, s := range results.stores {
+			storeLines = append(storeLines, fmt.Sprintf("%s - %s", s.Name, s.Endereco))
+		}
+		for _, q := range results.quotes {
+			quoteLines = append(quoteLines, T("search.quote_line", q.Product.Name, q.Store.Name, formatQuotePrice(q), q.Date.Format("2006-01-02")))
+		}
+
+		accordion := widget.NewAccordion(
+			widget.NewAccordionItem(fmt.Sprintf("%s (%d)", T("tab.products"), len(productLines)), searchResultItems(productLines, func(i int) {
+				choose("tab.products", results.products[i].ID)
+			})),
+			widget.NewAccordionItem(fmt.Sprintf("%s (%d)", T("tab.stores"), len(storeLines)), searchResultItems(storeLines, func(i int) {
+				choose("tab.stores", results.stores[i].ID)
+			})),
+			widget.NewAccordionItem(fmt.Sprintf("%s (%d)", T("tab.quotes"), len(quoteLines)), searchResultItems(quoteLines, func(i int) {
+				choose("tab.quote_search", results.quotes[i].ID)
+			})),
+		)
+		for i, n := range []int{len(productLines), len(storeLines), len(quoteLines)} {
+			if n > 0 {
+				accordion.Open(i)
+				break
+			}
+		}
+		scroll := container.NewVScroll(accordion)
+		scroll.SetMinSize(fyne.NewSize(600, 400))
+		dlg = dialog.NewCustom(T("search.results_title", strings.TrimSpace(term)), T("common.close"), scroll, w)
+		dlg.Show()
+	})
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+func priceHistory(productID uint) []Quote {
+	var quotes []Quote
+	db.Preload("Store").Preload("Product").Where("product_id = ?", productID).Order("date").Find(&quotes)
+	return quotes
+}
+
+func pricePerStandardUnit(q Quote) (float64, bool) {
+	divisor := q.PackagingSize * q.ConversionFactor
+	if divisor == 0 {
+		return 0, false
+	}
+	price, err := priceInBRL(q)
+	if err != nil {
+		return 0, false
+	}
+	return price / divisor, true
+}
+
+const defaultIncreaseThreshold = 10.0
+
+type storePrice struct {
+	store string
+	ppu   float64
+}
+
+func lowestPricesByStore(productID uint, date time.Time) map[uint]storePrice {
+	var quotes []Quote
+	db.Preload("Store").Where("product_id = ? AND date = ?", productID, date).Find(&quotes)
+	prices := make(map[uint]storePrice)
+	for _, q := range quotes {
+		ppu, ok := pricePerStandardUnit(q)
+		if !ok {
+			continue
+		}
+		if current, found := prices[q.StoreID]; !found || ppu < current.ppu {
+			prices[q.StoreID] = storePrice{q.Store.Name, ppu}
+		}
+	}
+	return prices
+}
+
+func generatePriceVariationReport(product Product, from, to time.Time, threshold float64) string {
+	before := lowestPricesByStore(product.ID, from)
+	after := lowestPricesByStore(product.ID, to)
+
+	var sb strings.Builder
+	sb.WriteString(T("history.variation_report", product.Name, from.Format("2006-01-02"), to.Format("2006-01-02"), product.StandardUnit))
+	if len(before) == 0 && len(after) == 0 {
+		sb.WriteString(T("history.no_quotes_both"))
+		return sb.String()
+	}
+
+	var both, onlyBefore, onlyAfter []uint
+	for id := range before {
+		if _, ok := after[id]; ok {
+			both = append(both, id)
+		} else {
+			onlyBefore = append(onlyBefore, id)
+		}
+	}
+	for id := range after {
+		if _, ok := before[id]; !ok {
+			onlyAfter = append(onlyAfter, id)
+		}
+	}
+	byName := func(ids []uint, prices map[uint]storePrice) {
+		sort.Slice(ids, func(i, j int) bool { return prices[ids[i]].store < prices[ids[j]].store })
+	}
+	byName(both, after)
+	byName(onlyBefore, before)
+	byName(onlyAfter, after)
+
+	significant := 0
+	for _, id := range both {
+		old, cur := before[id], after[id]
+		change := (cur.ppu - old.ppu) / old.ppu * 100
+		prefix := "  "
+		if change > threshold {
+			prefix = "⚠ "
+			significant++
+		}
+		sb.WriteString(T("history.variation_line", prefix, cur.store, old.ppu, cur.ppu, change))
+	}
+	if len(both) == 0 {
+		sb.WriteString(T("history.no_store_both"))
+	}
+	if significant > 0 {
+		sb.WriteString(T("history.significant", significant, formatFloat(threshold)))
+	}
+
+	if len(onlyBefore) > 0 {
+		sb.WriteString(T("history.only_on", from.Format("2006-01-02")))
+		for _, id := range onlyBefore {
+			sb.WriteString(T("history.only_line", before[id].store, before[id].ppu))
+		}
+	}
+	if len(onlyAfter) > 0 {
+		sb.WriteString(T("history.only_on", to.Format("2006-01-02")))
+		for _, id := range onlyAfter {
+			sb.WriteString(T("history.only_line", after[id].store, after[id].ppu))
+		}
+	}
+	return sb.String()
+}
+
+func historyTab(w fyne.Window) fyne.CanvasObject {
+	productSelect := widget.NewSelect(productOptions, func(s string) {})
+	startPicker := NewDatePicker()
+	endPicker := NewDatePicker()
+	thresholdEntry := newEntry()
+	thresholdEntry.SetText(formatFloat(defaultIncreaseThreshold))
+	summaryLabel := widget.NewLabel("")
+
+	var history []Quote
+	minIdx, maxIdx := -1, -1
+	headers := []string{T("compare.date"), T("compare.store"), T("compare.unit_price"), ""}
+
+	table := widget.NewTable(
+		func() (int, int) {
+			return len(history) + 1, len(headers)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("template template")
+		},
+		func(id widget.TableCellID, co fyne.CanvasObject) {
+			label := co.(*widget.Label)
+			label.Importance = widget.MediumImportance
+			label.TextStyle = fyne.TextStyle{}
+			if id.Row == 0 {
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				label.SetText(headers[id.Col])
+				return
+			}
+			q := history[id.Row-1]
+			if id.Row-1 == minIdx {
+				label.Importance = widget.SuccessImportance
+			} else if id.Row-1 == maxIdx {
+				label.Importance = widget.DangerImportance
+			}
+			switch id.Col {
+			case 0:
+				label.SetText(q.Date.Format("2006-01-02"))
+			case 1:
+				label.SetText(q.Store.Name)
+			case 2:
+				if ppu, ok := pricePerStandardUnit(q); ok {
+					label.SetText(fmt.Sprintf("R$ %.4f / %s", ppu, q.Product.StandardUnit))
+				} else {
+					label.SetText("N/A")
+				}
+			case 3:
+				switch id.Row - 1 {
+				case minIdx:
+					label.SetText(T("compare.lowest"))
+				case maxIdx:
+					label.SetText(T("history.highest"))
+				default:
+					label.SetText("")
+				}
+			}
+		},
+	)
+	table.SetColumnWidth(0, 110)
+	table.SetColumnWidth(1, 220)
+	table.SetColumnWidth(2, 200)
+	table.SetColumnWidth(3, 120)
+
+	showHistory := func() {
+		minIdx, maxIdx = -1, -1
+		for i, q := range history {
+			ppu, ok := pricePerStandardUnit(q)
+			if !ok {
+				continue
+			}
+			if minIdx < 0 {
+				minIdx, maxIdx = i, i
+				continue
+			}
+			if minPPU, _ := pricePerStandardUnit(history[minIdx]); ppu < minPPU {
+				minIdx = i
+			}
+			if maxPPU, _ := pricePerStandardUnit(history[maxIdx]); ppu > maxPPU {
+				maxIdx = i
+			}
+		}
+		if len(history) == 0 {
+			summaryLabel.SetText(T("history.no_quotes"))
+		} else {
+			summaryLabel.SetText(T("history.found", len(history)))
+		}
+		table.Refresh()
+	}
+
+	showBtn := newButton(T("history.show"), func() {
+		productID, ok := productMap[productSelect.Selected]
+		if !ok {
+			dialog.ShowError(errors.New(T("common.select_product")), w)
+			return
+		}
+		var loaded []Quote
+		runWithProgress(w, T("history.loading"), func() {
+			loaded = priceHistory(productID)
+		}, func() {
+			history = loaded
+			showHistory()
+		})
+	})
+
+	chartBtn := newButton(T("history.chart"), func() {
+		productID, ok := productMap[productSelect.Selected]
+		if !ok {
+			dialog.ShowError(errors.New(T("common.select_product")), w)
+			return
+		}
+		start, end, err := readDateRange(startPicker, endPicker)
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		var product Product
+		if err := db.First(&product, productID).Error; err != nil {
+			dialog.ShowError(errors.New(T("common.product_not_found")), w)
+			return
+		}
+		var series []chartSeries
+		runWithProgress(w, T("history.chart_loading"), func() {
+			series = priceSeries(productID, start, end)
+		}, func() {
+			if len(series) == 0 {
+				dialog.ShowInformation(T("history.chart_title"), T("history.chart_empty", product.Name, formatPeriod(start, end)), w)
+				return
+			}
+			title := T("history.chart_heading", product.Name, formatPeriod(start, end))
+			dialog.ShowCustom(title, T("common.close"), buildPriceChart(series, product.StandardUnit), w)
+		})
+	})
+
+	variationBtn := newButton(T("history.variation"), func() {
+		productID, ok := productMap[productSelect.Selected]
+		if !ok {
+			dialog.ShowError(errors.New(T("common.select_product")), w)
+			return
+		}
+		from, okFrom := startPicker.Date()
+		to, okTo := endPicker.Date()
+		if !okFrom || !okTo {
+			dialog.ShowError(errors.New(T("history.both_dates")), w)
+			return
+		}
+		threshold, err := strconv.ParseFloat(strings.TrimSpace(strings.ReplaceAll(thresholdEntry.Text, ",", ".")), 64)
+		if err != nil || threshold < 0 {
+			dialog.ShowError(errors.New(T("history.invalid_threshold")), w)
+			return
+		}
+		var product Product
+		if err := db.First(&product, productID).Error; err != nil {
+			dialog.ShowError(errors.New(T("common.product_not_found")), w)
+			return
+		}
+		var report string
+		runWithProgress(w, T("history.comparing"), func() {
+			report = generatePriceVariationReport(product, from, to, threshold)
+		}, func() {
+			label := widget.NewLabel(report)
+			scroll := container.NewVScroll(label)
+			scroll.SetMinSize(fyne.NewSize(600, 360))
+			dialog.ShowCustom(T("history.variation_title"), T("common.close"), scroll, w)
+		})
+	})
+
+	refreshBtn := newButton(T("history.refresh_products"), func() {
+		productOptions, productMap = loadProductOptions()
+		productSelect.Options = productOptions
+		productSelect.Refresh()
+	})
+	registerRefresh(refreshWidgets(func() {
+		productSelect.Options = productOptions
+		productSelect.Refresh()
+	}))
+
+	top := container.NewVBox(
+		widget.NewForm(
+			widget.NewFormItem(T("compare.product"), productSelect),
+			widget.NewFormItem(T("history.start_date"), startPicker),
+			widget.NewFormItem(T("history.end_date"), endPicker),
+			widget.NewFormItem(T("history.threshold"), thresholdEntry),
+		),
+		container.NewHBox(showBtn, chartBtn, variationBtn, refreshBtn),
+		summaryLabel,
+	)
+	return container.NewBorder(top, nil, nil, nil, table)
+}
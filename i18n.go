@@ -0,0 +1,114 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	langPortuguese = "pt-BR"
+	langSpanish    = "es"
+	prefLanguage   = "language"
+)
+
+var languageNames = map[string]string{
+	langPortuguese: "Português",
+	langSpanish:    "Español",
+}
+
+var languageOrder = []string{langPortuguese, langSpanish}
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+var (
+	messages        = loadMessages()
+	currentLanguage = langPortuguese
+)
+
+func loadMessages() map[string]map[string]string {
+	all := make(map[string]map[string]string)
+	for _, lang := range languageOrder {
+		data, err := localeFiles.ReadFile("locales/" + lang + ".json")
+		if err != nil {
+			slog.Warn("Arquivo de tradução não encontrado", "idioma", lang, "erro", err)
+			continue
+		}
+		m := make(map[string]string)
+		if err := json.Unmarshal(data, &m); err != nil {
+			slog.Error("Arquivo de tradução inválido", "idioma", lang, "erro", err)
+			continue
+		}
+		all[lang] = m
+	}
+	return all
+}
+
+func T(key string, args ...any) string {
+	msg, ok := messages[currentLanguage][key]
+	if !ok {
+		msg, ok = messages[langPortuguese][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+func setLanguage(a fyne.App, lang string) {
+	if _, ok := messages[lang]; !ok {
+		lang = langPortuguese
+	}
+	currentLanguage = lang
+	a.Preferences().SetString(prefLanguage, lang)
+}
+
+func loadSavedLanguage(a fyne.App) {
+	setLanguage(a, a.Preferences().StringWithFallback(prefLanguage, langPortuguese))
+}
+
+func languageSelector(onChanged func()) *widget.Select {
+	var names []string
+	for _, lang := range languageOrder {
+		names = append(names, languageNames[lang])
+	}
+	sel := widget.NewSelect(names, nil)
+	sel.SetSelected(languageNames[currentLanguage])
+	sel.OnChanged = func(name string) {
+		for lang, n := range languageNames {
+			if n == name && lang != currentLanguage {
+				setLanguage(fyne.CurrentApp(), lang)
+				onChanged()
+				return
+			}
+		}
+	}
+	return sel
+}
+
+// As opções de seleção são chaves estáveis (comparadas no código e gravadas nas
+// preferências); o usuário vê a tradução de cada uma.
+func translatedOptions(keys []string) []string {
+	labels := make([]string, len(keys))
+	for i, key := range keys {
+		labels[i] = T(key)
+	}
+	return labels
+}
+
+func optionKey(keys []string, label string) string {
+	for _, key := range keys {
+		if T(key) == label {
+			return key
+		}
+	}
+	return ""
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+const maxImageSize = 5 * 1024 * 1024
+
+var imageExtensions = []string{".png", ".jpg", ".jpeg"}
+
+func productImageDir() string {
+	if dir := os.Getenv("PRODUCT_IMAGE_DIR"); dir != "" {
+		return dir
+	}
+	return "imagens_produtos"
+}
+
+func storeProductImage(reader fyne.URIReadCloser) (string, error) {
+	ext := strings.ToLower(reader.URI().Extension())
+	valid := false
+	for _, e := range imageExtensions {
+		if ext == e {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return "", errors.New(T("images.unsupported"))
+	}
+
+	data, err := io.ReadAll(io.LimitReader(reader, maxImageSize+1))
+	if err != nil {
+		return "", errors.New(T("images.read_error", err))
+	}
+	if len(data) > maxImageSize {
+		return "", errors.New(T("images.too_large", maxImageSize/(1024*1024)))
+	}
+	if _, _, err := image.DecodeConfig(bytes.NewReader(data)); err != nil {
+		return "", errors.New(T("images.invalid"))
+	}
+
+	dir := productImageDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", errors.New(T("images.dir_error", err))
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%d%s", time.Now().UnixNano(), ext))
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", errors.New(T("images.save_error", err))
+	}
+	return path, nil
+}
+
+func imagePickerField(w fyne.Window, path *string) fyne.CanvasObject {
+	preview := canvas.NewImageFromFile(*path)
+	preview.FillMode = canvas.ImageFillContain
+	preview.SetMinSize(fyne.NewSize(120, 120))
+	if *path == "" {
+		preview.Hide()
+	}
+	label := widget.NewLabel(T("images.none"))
+	if *path != "" {
+		label.SetText(filepath.Base(*path))
+	}
+
+	var clearBtn *widget.Button
+	show := func() {
+		if *path == "" {
+			label.SetText(T("images.none"))
+			preview.Hide()
+			clearBtn.Disable()
+			return
+		}
+		label.SetText(filepath.Base(*path))
+		preview.File = *path
+		preview.Refresh()
+		preview.Show()
+		clearBtn.Enable()
+	}
+	chooseBtn := newButton(T("images.choose"), func() {
+		open := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			if reader == nil {
+				return
+			}
+			defer reader.Close()
+			stored, err := storeProductImage(reader)
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			*path = stored
+			show()
+		}, w)
+		open.SetFilter(storage.NewExtensionFileFilter(imageExtensions))
+		open.Show()
+	})
+	clearBtn = newButton(T("images.remove"), func() {
+		*path = ""
+		show()
+	})
+	show()
+	return container.NewVBox(container.NewHBox(chooseBtn, clearBtn, label), preview)
+}
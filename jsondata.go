@@ -0,0 +1,345 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const exportVersion = 2
+
+type exportProduct struct {
+	ID           uint    `json:"id"`
+	Name         string  `json:"name"`
+	StandardUnit string  `json:"standard_unit"`
+	Category     string  `json:"category"`
+	ImagePath    string  `json:"image_path,omitempty"`
+	Density      float64 `json:"density,omitempty"`
+}
+
+type exportContact struct {
+	Type  string `json:"type"`
+	Name  string `json:"name,omitempty"`
+	Phone string `json:"phone"`
+}
+
+type exportStore struct {
+	ID             uint            `json:"id"`
+	Name           string          `json:"name"`
+	Endereco       string          `json:"endereco"`
+	Telefone       string          `json:"telefone,omitempty"`
+	CNPJ           *string         `json:"cnpj,omitempty"`
+	Representative string          `json:"representative,omitempty"`
+	Preferred      bool            `json:"preferred,omitempty"`
+	Contacts       []exportContact `json:"contacts,omitempty"`
+}
+
+type exportGroup struct {
+	ID   uint      `json:"id"`
+	Name string    `json:"name"`
+	Date time.Time `json:"date"`
+}
+
+type exportTier struct {
+	MinQuantity float64 `json:"min_quantity"`
+	Price       float64 `json:"price"`
+}
+
+type exportQuote struct {
+	ID               uint         `json:"id"`
+	ProductID        uint         `json:"product_id"`
+	StoreID          uint         `json:"store_id"`
+	Price            float64      `json:"price"`
+	Currency         string       `json:"currency"`
+	PackagingSize    float64      `json:"packaging_size"`
+	PackagingUnit    string       `json:"packaging_unit"`
+	ConversionFactor float64      `json:"conversion_factor"`
+	Date             time.Time    `json:"date"`
+	Notes            string       `json:"notes,omitempty"`
+	MinOrderQuantity float64      `json:"min_order_quantity,omitempty"`
+	ShippingCost     float64      `json:"shipping_cost,omitempty"`
+	ShippingPerUnit  bool         `json:"shipping_per_unit,omitempty"`
+	ValidUntil       *time.Time   `json:"valid_until,omitempty"`
+	DeliveryDays     *int         `json:"delivery_days,omitempty"`
+	Tiers            []exportTier `json:"tiers,omitempty"`
+}
+
+type exportPrescription struct {
+	ID               uint       `json:"id"`
+	ProductID        uint       `json:"product_id"`
+	GroupID          uint       `json:"group_id"`
+	RequiredQuantity float64    `json:"required_quantity"`
+	RequiredUnit     string     `json:"required_unit"`
+	SeasonStart      *time.Time `json:"season_start,omitempty"`
+	SeasonEnd        *time.Time `json:"season_end,omitempty"`
+}
+
+type exportData struct {
+	Version       int                  `json:"version"`
+	ExportedAt    time.Time            `json:"exported_at"`
+	Products      []exportProduct      `json:"products"`
+	Stores        []exportStore        `json:"stores"`
+	Groups        []exportGroup        `json:"prescription_groups"`
+	Quotes        []exportQuote        `json:"quotes"`
+	Prescriptions []exportPrescription `json:"prescriptions"`
+}
+
+func collectExportData() exportData {
+	data := exportData{Version: exportVersion, ExportedAt: time.Now().UTC()}
+
+	var products []Product
+	db.Order("id").Find(&products)
+	for _, p := range products {
+		data.Products = append(data.Products, exportProduct{p.ID, p.Name, p.StandardUnit, p.Category, p.ImagePath, p.Density})
+	}
+
+	var stores []Store
+	db.Preload("Contacts").Order("id").Find(&stores)
+	for _, s := range stores {
+		store := exportStore{ID: s.ID, Name: s.Name, Endereco: s.Endereco, CNPJ: s.CNPJ, Representative: s.Representative, Preferred: s.Preferred}
+		for _, c := range s.Contacts {
+			store.Contacts = append(store.Contacts, exportContact{c.Type, c.Name, c.Phone})
+		}
+		data.Stores = append(data.Stores, store)
+	}
+
+	var groups []PrescriptionGroup
+	db.Order("id").Find(&groups)
+	for _, g := range groups {
+		data.Groups = append(data.Groups, exportGroup{g.ID, g.Name, g.Date})
+	}
+
+	var quotes []Quote
+	db.Preload("Tiers").Order("id").Find(&quotes)
+	for _, q := range quotes {
+		var tiers []exportTier
+		for _, t := range q.Tiers {
+			tiers = append(tiers, exportTier{t.MinQuantity, t.Price})
+		}
+		data.Quotes = append(data.Quotes, exportQuote{
+			ID:               q.ID,
+			ProductID:        q.ProductID,
+			StoreID:          q.StoreID,
+			Price:            q.Price,
+			Currency:         q.Currency,
+			PackagingSize:    q.PackagingSize,
+			PackagingUnit:    q.PackagingUnit,
+			ConversionFactor: q.ConversionFactor,
+			Date:             q.Date,
+			Notes:            q.Notes,
+			MinOrderQuantity: q.MinOrderQuantity,
+			ShippingCost:     q.ShippingCost,
+			ShippingPerUnit:  q.ShippingPerUnit,
+			ValidUntil:       q.ValidUntil,
+			DeliveryDays:     q.DeliveryDays,
+			Tiers:            tiers,
+		})
+	}
+
+	var prescriptions []Prescription
+	db.Order("id").Find(&prescriptions)
+	for _, p := range prescriptions {
+		data.Prescriptions = append(data.Prescriptions, exportPrescription{p.ID, p.ProductID, p.GroupID, p.RequiredQuantity, p.RequiredUnit, p.SeasonStart, p.SeasonEnd})
+	}
+	return data
+}
+
+func writeExportJSON(out io.Writer, data exportData) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(data)
+}
+
+type importSummary struct {
+	products, productsReused            int
+	stores, storesReused                int
+	groups, groupsReused                int
+	restored                            int
+	quotes, quotesSkipped               int
+	prescriptions, prescriptionsSkipped int
+}
+
+func (s importSummary) String() string {
+	var sb strings.Builder
+	sb.WriteString(T("import.products", s.products, s.productsReused) + "\n")
+	sb.WriteString(T("import.stores", s.stores, s.storesReused) + "\n")
+	sb.WriteString(T("import.groups", s.groups, s.groupsReused) + "\n")
+	sb.WriteString(T("import.quotes", s.quotes, s.quotesSkipped) + "\n")
+	sb.WriteString(T("import.prescriptions", s.prescriptions, s.prescriptionsSkipped))
+	if s.restored > 0 {
+		sb.WriteString("\n" + T("import.restored", s.restored))
+	}
+	return sb.String()
+}
+
+func readExportJSON(in io.Reader) (exportData, error) {
+	var data exportData
+	if err := json.NewDecoder(in).Decode(&data); err != nil {
+		return data, errors.New(T("import.invalid_json", err))
+	}
+	if data.Version == 0 || data.Version > exportVersion {
+		return data, errors.New(T("import.unsupported_version", data.Version))
+	}
+	return data, nil
+}
+
+// O índice único de nome também cobre os registros na lixeira, então a busca
+// por nome inclui os excluídos e quem estiver lá é restaurado em vez de recriado.
+func restoreIfDeleted(tx *gorm.DB, model interface{}, deletedAt gorm.DeletedAt, summary *importSummary) error {
+	if !deletedAt.Valid {
+		return nil
+	}
+	summary.restored++
+	return tx.Unscoped().Model(model).Update("deleted_at", nil).Error
+}
+
+func importJSONData(data exportData) (importSummary, error) {
+	var summary importSummary
+	err := db.Transaction(func(tx *gorm.DB) error {
+		productIDs := make(map[uint]uint)
+		for _, p := range data.Products {
+			var existing Product
+			if err := tx.Unscoped().Where("name = ?", p.Name).First(&existing).Error; err == nil {
+				if err := restoreIfDeleted(tx, &existing, existing.DeletedAt, &summary); err != nil {
+					return fmt.Errorf("%s: %w", T("import.product_ref", p.Name), err)
+				}
+				productIDs[p.ID] = existing.ID
+				summary.productsReused++
+				continue
+			}
+			category := p.Category
+			if category == "" {
+				category = defaultCategory
+			}
+			product := Product{Name: p.Name, StandardUnit: p.StandardUnit, Category: category, ImagePath: p.ImagePath, Density: p.Density}
+			if err := tx.Create(&product).Error; err != nil {
+				return fmt.Errorf("%s: %w", T("import.product_ref", p.Name), err)
+			}
+			productIDs[p.ID] = product.ID
+			summary.products++
+		}
+
+		storeIDs := make(map[uint]uint)
+		for _, s := range data.Stores {
+			var existing Store
+			if err := tx.Unscoped().Where("name = ?", s.Name).First(&existing).Error; err == nil {
+				if err := restoreIfDeleted(tx, &existing, existing.DeletedAt, &summary); err != nil {
+					return fmt.Errorf("%s: %w", T("import.store_ref", s.Name), err)
+				}
+				storeIDs[s.ID] = existing.ID
+				summary.storesReused++
+				continue
+			}
+			cnpj := s.CNPJ
+			if cnpj != nil {
+				var other Store
+				if err := tx.Unscoped().Where("cnpj = ?", *cnpj).First(&other).Error; err == nil {
+					cnpj = nil
+				}
+			}
+			store := Store{Name: s.Name, Endereco: s.Endereco, CNPJ: cnpj, Representative: s.Representative, Preferred: s.Preferred}
+			for _, c := range s.Contacts {
+				store.Contacts = append(store.Contacts, StoreContact{Type: c.Type, Name: c.Name, Phone: c.Phone})
+			}
+			if len(s.Contacts) == 0 && s.Telefone != "" {
+				store.Contacts = []StoreContact{{Type: contactPrincipal, Phone: s.Telefone}}
+			}
+			if err := tx.Create(&store).Error; err != nil {
+				return fmt.Errorf("%s: %w", T("import.store_ref", s.Name), err)
+			}
+			storeIDs[s.ID] = store.ID
+			summary.stores++
+		}
+
+		groupIDs := make(map[uint]uint)
+		for _, g := range data.Groups {
+			var existing PrescriptionGroup
+			if err := tx.Unscoped().Where("name = ?", g.Name).First(&existing).Error; err == nil {
+				if err := restoreIfDeleted(tx, &existing, existing.DeletedAt, &summary); err != nil {
+					return fmt.Errorf("%s: %w", T("import.group_ref", g.Name), err)
+				}
+				groupIDs[g.ID] = existing.ID
+				summary.groupsReused++
+				continue
+			}
+			group := PrescriptionGroup{Name: g.Name, Date: g.Date}
+			if err := tx.Create(&group).Error; err != nil {
+				return fmt.Errorf("%s: %w", T("import.group_ref", g.Name), err)
+			}
+			groupIDs[g.ID] = group.ID
+			summary.groups++
+		}
+
+		for _, q := range data.Quotes {
+			productID, okProduct := productIDs[q.ProductID]
+			storeID, okStore := storeIDs[q.StoreID]
+			if !okProduct || !okStore {
+				summary.quotesSkipped++
+				continue
+			}
+			var count int64
+			tx.Model(&Quote{}).Where("product_id = ? AND store_id = ? AND date = ? AND price = ?", productID, storeID, q.Date, q.Price).Count(&count)
+			if count > 0 {
+				summary.quotesSkipped++
+				continue
+			}
+			currency := q.Currency
+			if currency == "" {
+				currency = defaultCurrency
+			}
+			quote := Quote{
+				ProductID:        productID,
+				StoreID:          storeID,
+				Price:            q.Price,
+				Currency:         currency,
+				PackagingSize:    q.PackagingSize,
+				PackagingUnit:    q.PackagingUnit,
+				ConversionFactor: q.ConversionFactor,
+				Date:             q.Date,
+				Notes:            q.Notes,
+				MinOrderQuantity: q.MinOrderQuantity,
+				ShippingCost:     q.ShippingCost,
+				ShippingPerUnit:  q.ShippingPerUnit,
+				ValidUntil:       q.ValidUntil,
+				DeliveryDays:     q.DeliveryDays,
+			}
+			for _, t := range q.Tiers {
+				quote.Tiers = append(quote.Tiers, PriceTier{MinQuantity: t.MinQuantity, Price: t.Price})
+			}
+			if currentUser != nil {
+				quote.UserID = &currentUser.ID
+			}
+			if err := tx.Create(&quote).Error; err != nil {
+				return fmt.Errorf("%s: %w", T("import.quote_ref", q.ID), err)
+			}
+			summary.quotes++
+		}
+
+		for _, p := range data.Prescriptions {
+			productID, okProduct := productIDs[p.ProductID]
+			groupID, okGroup := groupIDs[p.GroupID]
+			if !okProduct || !okGroup {
+				summary.prescriptionsSkipped++
+				continue
+			}
+			var count int64
+			tx.Model(&Prescription{}).Where("product_id = ? AND group_id = ?", productID, groupID).Count(&count)
+			if count > 0 {
+				summary.prescriptionsSkipped++
+				continue
+			}
+			pres := Prescription{ProductID: productID, GroupID: groupID, RequiredQuantity: p.RequiredQuantity, RequiredUnit: p.RequiredUnit, SeasonStart: p.SeasonStart, SeasonEnd: p.SeasonEnd}
+			if err := tx.Create(&pres).Error; err != nil {
+				return fmt.Errorf("%s: %w", T("import.prescription_ref", p.ID), err)
+			}
+			summary.prescriptions++
+		}
+		return nil
+	})
+	return summary, err
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	maxFailedLogins = 5
+	lockoutDuration = 5 * time.Minute
+)
+
+func lockoutRemaining(user User, now time.Time) time.Duration {
+	if user.LockedUntil == nil || !user.LockedUntil.After(now) {
+		return 0
+	}
+	return user.LockedUntil.Sub(now)
+}
+
+func registerFailedLogin(user *User, now time.Time) (int, error) {
+	user.FailedAttempts++
+	if user.FailedAttempts >= maxFailedLogins {
+		until := now.Add(lockoutDuration)
+		user.LockedUntil = &until
+		user.FailedAttempts = 0
+	}
+	err := db.Model(user).Select("FailedAttempts", "LockedUntil").Updates(user).Error
+	return maxFailedLogins - user.FailedAttempts, err
+}
+
+func resetFailedLogins(user *User) error {
+	if user.FailedAttempts == 0 && user.LockedUntil == nil {
+		return nil
+	}
+	user.FailedAttempts = 0
+	user.LockedUntil = nil
+	return db.Model(user).Select("FailedAttempts", "LockedUntil").Updates(user).Error
+}
+
+func formatLockout(d time.Duration) string {
+	d = d.Round(time.Second)
+	minutes := int(d / time.Minute)
+	seconds := int((d % time.Minute) / time.Second)
+	if minutes == 0 {
+		return fmt.Sprintf("%ds", seconds)
+	}
+	return fmt.Sprintf("%dmin %02ds", minutes, seconds)
+}
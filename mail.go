@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"mime"
+	"mime/multipart"
+	"net/smtp"
+	"net/textproto"
+	"os"
+)
+
+func smtpConfigured() bool {
+	return os.Getenv("SMTP_HOST") != "" && os.Getenv("SMTP_PORT") != "" && os.Getenv("SMTP_FROM") != ""
+}
+
+type emailAttachment struct {
+	fileName    string
+	contentType string
+	data        []byte
+}
+
+func sendEmail(to, subject, body string) error {
+	return sendEmailWithAttachment(to, subject, body, nil)
+}
+
+func sendEmailWithAttachment(to, subject, body string, attachment *emailAttachment) error {
+	if !smtpConfigured() {
+		return errors.New(T("mail.not_configured"))
+	}
+
+	host := os.Getenv("SMTP_HOST")
+	port := os.Getenv("SMTP_PORT")
+	user := os.Getenv("SMTP_USER")
+	pass := os.Getenv("SMTP_PASSWORD")
+	from := os.Getenv("SMTP_FROM")
+
+	var auth smtp.Auth
+	if user != "" {
+		auth = smtp.PlainAuth("", user, pass, host)
+	}
+
+	var msg bytes.Buffer
+	msg.WriteString("From: " + from + "\r\n")
+	msg.WriteString("To: " + to + "\r\n")
+	msg.WriteString("Subject: " + mime.QEncoding.Encode("UTF-8", subject) + "\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	if attachment == nil {
+		msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+		msg.WriteString("\r\n")
+		msg.WriteString(body)
+	} else if err := writeMultipartBody(&msg, body, attachment); err != nil {
+		return errors.New(T("mail.build_error", err))
+	}
+
+	if err := smtp.SendMail(host+":"+port, auth, from, []string{to}, msg.Bytes()); err != nil {
+		var tpErr *textproto.Error
+		if errors.As(err, &tpErr) && (tpErr.Code == 530 || tpErr.Code == 534 || tpErr.Code == 535) {
+			return errors.New(T("mail.auth_error", tpErr.Msg))
+		}
+		return errors.New(T("mail.send_error", err))
+	}
+	return nil
+}
+
+func writeMultipartBody(msg *bytes.Buffer, body string, attachment *emailAttachment) error {
+	mw := multipart.NewWriter(msg)
+	msg.WriteString("Content-Type: multipart/mixed; boundary=" + mw.Boundary() + "\r\n")
+	msg.WriteString("\r\n")
+
+	textPart, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {"text/plain; charset=UTF-8"},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := textPart.Write([]byte(body)); err != nil {
+		return err
+	}
+
+	filePart, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {attachment.contentType},
+		"Content-Transfer-Encoding": {"base64"},
+		"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.fileName})},
+	})
+	if err != nil {
+		return err
+	}
+	encoded := base64.StdEncoding.EncodeToString(attachment.data)
+	for len(encoded) > 76 {
+		if _, err := filePart.Write([]byte(encoded[:76] + "\r\n")); err != nil {
+			return err
+		}
+		encoded = encoded[76:]
+	}
+	if _, err := filePart.Write([]byte(encoded + "\r\n")); err != nil {
+		return err
+	}
+	return mw.Close()
+}
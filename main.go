@@ -1,9 +1,16 @@
 package main
 
 import (
+	"bytes"
+	"crypto/rand"
+	"errors"
 	"fmt"
 	"log"
+	"log/slog"
+	"math"
+	"math/big"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -13,10 +20,12 @@ import (
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/widget"
 	"github.com/joho/godotenv"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
@@ -25,10 +34,25 @@ var productOptions []string
 var productMap map[string]uint
 var storeOptions []string
 var storeMap map[string]uint
+var groupOptions []string
+var groupMap map[string]uint
 var productsList []Product
 var storesList []Store
 var quotesList []Quote
 var prescriptionsList []Prescription
+var usersList []User
+var currentUser *User
+
+const defaultQuotePageSize = 50
+const allGroupsOption = "report.all_groups"
+const allCategoriesOption = "product.all_categories"
+
+// defaultCategory é o valor gravado no banco (também o default da coluna); na
+// tela ele aparece traduzido por categoryLabel.
+const defaultCategory = "Sem categoria"
+const defaultCategoryKey = "product.no_category"
+
+var defaultCategories = []string{"Fertilizantes", "Defensivos", "Sementes", "Corretivos", "Adjuvantes", defaultCategory}
 
 type User struct {
 	gorm.Model
@@ -36,32 +60,58 @@ type User struct {
 	Password string `gorm:"not null"`
 	FullName string `gorm:"not null"`
 	Email    string `gorm:"unique;not null"`
+	Role     string `gorm:"not null;default:user"`
+
+	FailedAttempts int `gorm:"not null;default:0"`
+	LockedUntil    *time.Time
 }
 
 type Product struct {
 	gorm.Model
 	Name         string `gorm:"unique;not null"`
 	StandardUnit string `gorm:"not null"`
+	Category     string `gorm:"not null;default:'Sem categoria'"`
+	ImagePath    string
+	Density      float64
 }
 
 type Store struct {
 	gorm.Model
-	Name     string `gorm:"unique;not null"`
-	Endereco string `gorm:"unique;not null"`
-	Telefone string `gorm:"unique"`
+	Name           string  `gorm:"unique;not null"`
+	Endereco       string  `gorm:"not null"`
+	CNPJ           *string `gorm:"unique"`
+	Representative string
+	Preferred      bool `gorm:"not null;default:false"`
+	Contacts       []StoreContact
 }
 
 type Quote struct {
 	gorm.Model
-	ProductID        uint      `gorm:"not null"`
-	StoreID          uint      `gorm:"not null"`
+	ProductID        uint      `gorm:"not null;index:idx_quote_product_store_date"`
+	StoreID          uint      `gorm:"not null;index:idx_quote_product_store_date"`
 	Price            float64   `gorm:"not null"`
+	Currency         string    `gorm:"not null;default:BRL"`
 	PackagingSize    float64   `gorm:"not null"`
 	PackagingUnit    string    `gorm:"not null"`
 	ConversionFactor float64   `gorm:"not null;default:1.0"`
-	Date             time.Time `gorm:"not null"`
-	Product          Product   `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
-	Store            Store     `gorm:"foreignKey:StoreID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	Date             time.Time `gorm:"not null;index:idx_quote_product_store_date"`
+	Notes            string
+	MinOrderQuantity float64
+	ShippingCost     float64 `gorm:"not null;default:0"`
+	ShippingPerUnit  bool    `gorm:"not null;default:false"`
+	ValidUntil       *time.Time
+	DeliveryDays     *int
+	UserID           *uint
+	Product          Product `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	Store            Store   `gorm:"foreignKey:StoreID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	User             User    `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	Tiers            []PriceTier
+}
+
+type PrescriptionGroup struct {
+	gorm.Model
+	Name string    `gorm:"unique;not null"`
+	Date time.Time `gorm:"not null"`
 }
 
 type Prescription struct {
@@ -69,36 +119,68 @@ type Prescription struct {
 	ProductID        uint    `gorm:"not null"`
 	RequiredQuantity float64 `gorm:"not null"`
 	RequiredUnit     string  `gorm:"not null"`
-	Product          Product `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	GroupID          uint    `gorm:"index"`
+	SeasonStart      *time.Time
+	SeasonEnd        *time.Time
+	Product          Product           `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	Group            PrescriptionGroup `gorm:"foreignKey:GroupID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
 }
 
 func Conectar() {
 	err := godotenv.Load()
 	if err != nil {
-		log.Fatal("Erro ao carregar .env:", err)
+		slog.Warn("Arquivo .env não carregado, usando variáveis de ambiente do sistema", "erro", err)
+	}
+	setupLogging()
+
+	driver := strings.ToLower(strings.TrimSpace(os.Getenv("DB_DRIVER")))
+	var dialector gorm.Dialector
+	switch driver {
+	case "", "postgres":
+		driver = "postgres"
+		requireEnv("DB_HOST", "DB_PORT", "DB_USER", "DB_NAME")
+		user := os.Getenv("DB_USER")
+		pass := os.Getenv("DB_PASSWORD")
+		host := os.Getenv("DB_HOST")
+		port := os.Getenv("DB_PORT")
+		dbname := os.Getenv("DB_NAME")
+
+		dsn := fmt.Sprintf(
+			"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
+			host, user, pass, dbname, port,
+		)
+		dialector = postgres.Open(dsn)
+	case "sqlite":
+		path := os.Getenv("DB_PATH")
+		if path == "" {
+			path = "cotacao.db"
+		}
+		sqlitePath = path
+		dialector = sqlite.Open(path)
+	default:
+		log.Fatalf("DB_DRIVER '%s' não reconhecido. Use 'postgres' ou 'sqlite'.", driver)
 	}
 
-	user := os.Getenv("DB_USER")
-	pass := os.Getenv("DB_PASSWORD")
-	host := os.Getenv("DB_HOST")
-	port := os.Getenv("DB_PORT")
-	dbname := os.Getenv("DB_NAME")
-
-	dsn := fmt.Sprintf(
-		"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
-		host, user, pass, dbname, port,
-	)
-
-	db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	dbDialector = dialector
+	dbDriver = driver
+	db, err = openWithRetry()
 	if err != nil {
-		panic("Falha ao conectar ao banco de dados postgres: " + err.Error())
+		panic("Falha ao conectar ao banco de dados " + driver + ": " + err.Error())
 	}
 
-	if err := db.AutoMigrate(&User{}, &Product{}, &Store{}, &Quote{}, &Prescription{}); err != nil {
-		panic("Erro ao executar migração: " + err.Error())
-	} else {
-		fmt.Println("Conectado com sucesso. Migração concluída.")
+	if err := migrateDatabase(); err != nil {
+		panic(err.Error())
+	}
+	slog.Info("Conectado com sucesso. Migração concluída.", "driver", driver)
+}
+
+func migrateDatabase() error {
+	if err := db.AutoMigrate(&User{}, &Product{}, &Store{}, &Quote{}, &PrescriptionGroup{}, &Prescription{}, &AuditLog{}, &StoreContact{}, &PriceTier{}); err != nil {
+		return errors.New(T("db.migration_error", err))
 	}
+	dropUniqueConstraint(&Store{}, "stores", "telefone")
+	dropUniqueConstraint(&Store{}, "stores", "endereco")
+	migrateStorePhones()
 
 	var count int64
 	db.Model(&User{}).Count(&count)
@@ -109,195 +191,462 @@ func Conectar() {
 			Password: string(hashedPassword),
 			FullName: "Administrador",
 			Email:    "admin@example.com",
+			Role:     roleAdmin,
 		})
-		fmt.Println("Usuário padrão 'admin' criado com sucesso.")
+		slog.Info("Usuário padrão 'admin' criado com sucesso.")
+	}
+
+	var adminCount int64
+	db.Model(&User{}).Where("role = ?", roleAdmin).Count(&adminCount)
+	if adminCount == 0 {
+		db.Model(&User{}).Where("username = ?", "admin").Update("role", roleAdmin)
+	}
+
+	var orphanCount int64
+	db.Model(&Prescription{}).Where("group_id IS NULL OR group_id = 0").Count(&orphanCount)
+	if orphanCount > 0 {
+		var group PrescriptionGroup
+		if err := db.Where(PrescriptionGroup{Name: "Avulsos"}).Attrs(PrescriptionGroup{Date: time.Now()}).FirstOrCreate(&group).Error; err != nil {
+			return errors.New(T("db.default_group_error", err))
+		}
+		db.Model(&Prescription{}).Where("group_id IS NULL OR group_id = 0").Update("group_id", group.ID)
+		slog.Info("Receituários atribuídos à receita 'Avulsos'", "quantidade", orphanCount)
+	}
+
+	db.Model(&Product{}).Where("category IS NULL OR category = ''").Update("category", defaultCategory)
+	return nil
+}
+
+func requireEnv(keys ...string) {
+	var missing []string
+	for _, key := range keys {
+		if os.Getenv(key) == "" {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		log.Fatalf("Variáveis de ambiente obrigatórias ausentes: %s", strings.Join(missing, ", "))
 	}
 }
 
 func main() {
 	Conectar()
-	productOptions, productMap = loadProductOptions()
-	storeOptions, storeMap = loadStoreOptions()
 
-	a := app.New()
-	w := a.NewWindow("Sistema de Cotação de Produto Agricola")
+	a := app.NewWithID("br.com.fazendasequencia.cotacao")
+	loadSavedTheme(a)
+	loadSavedLanguage(a)
+	loadPreferredTolerance(a)
+	w := a.NewWindow(T("app.title"))
 
 	loginTab := loginScreen(w)
 	w.SetContent(loginTab)
-	w.Resize(fyne.NewSize(800, 600))
+	restoreWindowSize(a, w)
+	w.SetCloseIntercept(func() {
+		saveWindowSize(a, w)
+		w.Close()
+	})
 	w.ShowAndRun()
 }
 
 func loginScreen(w fyne.Window) fyne.CanvasObject {
-	usernameEntry := widget.NewEntry()
-	passwordEntry := widget.NewPasswordEntry()
+	usernameEntry := newEntry()
+	passwordEntry := newPasswordEntry()
 
 	form := widget.NewForm(
-		widget.NewFormItem("Usuário", usernameEntry),
-		widget.NewFormItem("Senha", passwordEntry),
+		widget.NewFormItem(T("login.username"), usernameEntry),
+		widget.NewFormItem(T("login.password"), passwordEntry),
 	)
 
-	loginBtn := widget.NewButton("Login", func() {
+	loginBtn := newButton(T("login.button"), func() {
+		if !connectionAvailable(w) {
+			return
+		}
 		var user User
 		if err := db.Where("username = ?", usernameEntry.Text).First(&user).Error; err != nil {
-			dialog.ShowError(fmt.Errorf("Usuário não encontrado"), w)
+			slog.Warn("Falha de login: usuário não encontrado", "usuario", usernameEntry.Text)
+			dialog.ShowError(errors.New(T("common.user_not_found")), w)
+			return
+		}
+		now := time.Now()
+		if remaining := lockoutRemaining(user, now); remaining > 0 {
+			slog.Warn("Falha de login: usuário bloqueado", "usuario", user.Username)
+			dialog.ShowError(errors.New(T("login.locked", formatLockout(remaining))), w)
 			return
 		}
 		if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(passwordEntry.Text)); err != nil {
-			dialog.ShowError(fmt.Errorf("Senha incorreta"), w)
+			slog.Warn("Falha de login: senha incorreta", "usuario", user.Username)
+			left, err := registerFailedLogin(&user, now)
+			if err != nil {
+				showDBError(err, w)
+				return
+			}
+			if remaining := lockoutRemaining(user, now); remaining > 0 {
+				recordAudit("bloquear", "Usuário", user.ID, fmt.Sprintf("%s (%d tentativas falhas)", user.Username, maxFailedLogins))
+				dialog.ShowError(errors.New(T("login.now_locked", formatLockout(remaining))), w)
+				return
+			}
+			dialog.ShowError(errors.New(T("login.wrong_password_remaining", left)), w)
 			return
 		}
-		dialog.ShowInformation("Sucesso", "Login realizado!", w)
-		tabs := container.NewAppTabs(
-			container.NewTabItem("Produtos", productTab(w)),
-			container.NewTabItem("Lojas", storeTab(w)),
-			container.NewTabItem("Cotações", quoteTab(w)),
-			container.NewTabItem("Receituários", prescriptionTab(w)),
-			container.NewTabItem("Relatórios", reportTab(w)),
-		)
-		w.SetContent(tabs)
+		if err := resetFailedLogins(&user); err != nil {
+			showDBError(err, w)
+			return
+		}
+		setCurrentUser(user)
+		slog.Info("Login realizado", "usuario", user.Username, "perfil", user.Role)
+		dialog.ShowInformation(T("common.success"), T("login.success"), w)
+		w.SetContent(mainScreen(w))
 	})
 
-	registerBtn := widget.NewButton("Cadastrar Novo Usuário", func() {
+	registerBtn := newButton(T("login.register"), func() {
 		w.SetContent(registerScreen(w))
 	})
 
-	return container.NewVBox(form, loginBtn, registerBtn)
+	forgotBtn := newButton(T("login.forgot"), func() {
+		forgotPasswordDialog(w)
+	})
+
+	langSelect := languageSelector(func() {
+		w.SetTitle(T("app.title"))
+		w.SetContent(loginScreen(w))
+	})
+	langBar := container.NewHBox(layout.NewSpacer(), widget.NewLabel(T("main.language")), langSelect)
+
+	return container.NewVBox(langBar, form, loginBtn, registerBtn, forgotBtn)
+}
+
+func forgotPasswordDialog(w fyne.Window) {
+	emailEntry := newEntry()
+	items := []*widget.FormItem{
+		widget.NewFormItem(T("forgot.email"), emailEntry),
+	}
+	dlg := dialog.NewForm(T("forgot.title"), T("common.send"), T("common.cancel"), items, func(ok bool) {
+		if !ok {
+			return
+		}
+		email, err := validateEmail(emailEntry.Text)
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		var user User
+		if err := db.Where("email = ?", email).First(&user).Error; err != nil {
+			dialog.ShowError(errors.New(T("forgot.not_found")), w)
+			return
+		}
+		tempPassword, err := generateTempPassword(10)
+		if err != nil {
+			dialog.ShowError(errors.New(T("forgot.temp_error", err)), w)
+			return
+		}
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(tempPassword), bcrypt.DefaultCost)
+		if err != nil {
+			dialog.ShowError(errors.New(T("common.password_hash_error", err)), w)
+			return
+		}
+		// O e-mail sai antes de gravar a nova senha: se o envio falhar, a senha
+		// antiga continua valendo e o usuário não fica sem acesso.
+		if smtpConfigured() {
+			body := T("forgot.email_body", user.FullName, tempPassword)
+			if err := sendEmail(user.Email, T("forgot.email_subject"), body); err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+		}
+		user.Password = string(hashedPassword)
+		user.FailedAttempts = 0
+		user.LockedUntil = nil
+		if err := db.Save(&user).Error; err != nil {
+			showDBError(err, w)
+			return
+		}
+		recordAudit(auditUpdate, "Usuário", user.ID, user.Username)
+		if smtpConfigured() {
+			dialog.ShowInformation(T("common.success"), T("forgot.email_sent"), w)
+			return
+		}
+		dialog.ShowInformation(T("forgot.temp_title"), T("forgot.temp_message", tempPassword), w)
+	}, w)
+	dlg.Show()
+}
+
+func generateTempPassword(length int) (string, error) {
+	const chars = "abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	b := make([]byte, length)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+		if err != nil {
+			return "", err
+		}
+		b[i] = chars[n.Int64()]
+	}
+	return string(b), nil
+}
+
+func mainScreen(w fyne.Window) fyne.CanvasObject {
+	productOptions, productMap = loadProductOptions()
+	storeOptions, storeMap = loadStoreOptions()
+	groupOptions, groupMap = loadGroupOptions()
+
+	userLabel := widget.NewLabel("")
+	showUser := func() {
+		if currentUser != nil {
+			userLabel.SetText(T("main.logged_as", currentUser.FullName))
+		}
+	}
+	showUser()
+
+	tabs := container.NewAppTabs()
+	tabKeys := make(map[*container.TabItem]string)
+	addTab := func(key string, content fyne.CanvasObject) {
+		item := container.NewTabItem(T(key), content)
+		tabKeys[item] = key
+		tabs.Append(item)
+	}
+	dashboard, refreshDashboard := dashboardTab()
+	registerRefresh(refreshDashboard)
+	addTab("tab.home", dashboard)
+	if canViewAdminTabs() {
+		addTab("tab.products", productTab(w))
+		addTab("tab.stores", storeTab(w))
+	}
+	addTab("tab.quotes", quoteTab(w))
+	addTab("tab.quote_search", quoteSearchTab(w))
+	addTab("tab.prescriptions", prescriptionTab(w))
+	addTab("tab.reports", reportTab(w))
+	addTab("tab.history", historyTab(w))
+	addTab("tab.simulator", simulatorTab(w))
+	addTab("tab.profile", profileTab(w, showUser))
+	addTab("tab.change_password", changePasswordTab(w))
+	if canViewAdminTabs() {
+		addTab("tab.users", userTab(w))
+		addTab("tab.audit", auditTab(w))
+		addTab("tab.trash", trashTab(w))
+		addTab("tab.backup", backupTab(w))
+	}
+
+	restoreLastTab(tabs, tabKeys)
+	tabs.OnSelected = func(item *container.TabItem) {
+		touchSession()
+		saveLastTab(tabKeys[item])
+		if item.Content == dashboard {
+			refreshDashboard.now()
+		}
+	}
+	installShortcuts(w, tabs, tabKeys)
+
+	logoutBtn := newButton(T("main.logout"), func() {
+		logout(w)
+	})
+	langSelect := languageSelector(func() {
+		removeShortcuts(w)
+		w.SetTitle(T("app.title"))
+		w.SetContent(mainScreen(w))
+	})
+	openRecord := func(tabKey string, id uint) {
+		for _, item := range tabs.Items {
+			if tabKeys[item] != tabKey {
+				continue
+			}
+			tabs.Select(item)
+			if focus, ok := recordFocusers[tabKey]; ok {
+				focus(id)
+			}
+			return
+		}
+		dialog.ShowInformation(T("search.title"), T("search.no_access"), w)
+	}
+	globalSearchEntry := newEntry()
+	globalSearchEntry.SetPlaceHolder(T("search.placeholder"))
+	globalSearchEntry.OnSubmitted = func(term string) {
+		showGlobalSearch(w, term, openRecord)
+	}
+	globalSearchBtn := newButton(T("common.search"), func() {
+		showGlobalSearch(w, globalSearchEntry.Text, openRecord)
+	})
+	refreshAllBtn := newButton(T("refresh.all"), func() {
+		if connectionAvailable(w) {
+			refreshAll(w)
+		}
+	})
+	searchBar := container.NewBorder(nil, nil, nil, container.NewHBox(globalSearchBtn, refreshAllBtn), globalSearchEntry)
+
+	topBar := container.NewHBox(userLabel, layout.NewSpacer(), widget.NewLabel(T("main.language")), langSelect, widget.NewLabel(T("main.theme")), themeSelector(), logoutBtn)
+
+	return withSessionTimeout(w, container.NewBorder(container.NewVBox(topBar, searchBar), nil, nil, nil, tabs))
+}
+
+func setCurrentUser(u User) {
+	currentUser = &u
+}
+
+func clearCurrentUser() {
+	currentUser = nil
+}
+
+func isAdmin() bool {
+	return currentUser != nil && currentUser.Role == roleAdmin
+}
+
+func logout(w fyne.Window) {
+	if currentUser != nil {
+		slog.Info("Logout realizado", "usuario", currentUser.Username)
+	}
+	clearCurrentUser()
+	productsList = nil
+	storesList = nil
+	quotesList = nil
+	prescriptionsList = nil
+	usersList = nil
+	productOptions, productMap = nil, nil
+	storeOptions, storeMap = nil, nil
+	groupOptions, groupMap = nil, nil
+	invalidateProductCache()
+	invalidateStoreCache()
+	removeShortcuts(w)
+	stopSessionTimeout()
+	w.SetContent(loginScreen(w))
 }
 
 func registerScreen(w fyne.Window) fyne.CanvasObject {
-	usernameEntry := widget.NewEntry()
-	fullNameEntry := widget.NewEntry()
-	emailEntry := widget.NewEntry()
-	passwordEntry := widget.NewPasswordEntry()
-	confirmPasswordEntry := widget.NewPasswordEntry()
+	usernameEntry := newEntry()
+	fullNameEntry := newEntry()
+	emailEntry := newEntry()
+	passwordEntry := newPasswordEntry()
+	confirmPasswordEntry := newPasswordEntry()
 
 	form := widget.NewForm(
-		widget.NewFormItem("Usuário", usernameEntry),
-		widget.NewFormItem("Nome Completo", fullNameEntry),
-		widget.NewFormItem("E-mail", emailEntry),
-		widget.NewFormItem("Senha", passwordEntry),
-		widget.NewFormItem("Confirmar Senha", confirmPasswordEntry),
+		widget.NewFormItem(T("login.username"), usernameEntry),
+		widget.NewFormItem(T("register.full_name"), fullNameEntry),
+		widget.NewFormItem(T("register.email"), emailEntry),
+		widget.NewFormItem(T("login.password"), passwordEntry),
+		widget.NewFormItem(T("password.strength_label"), passwordStrengthIndicator(passwordEntry)),
+		widget.NewFormItem(T("register.confirm_password"), confirmPasswordEntry),
 	)
 
-	registerBtn := widget.NewButton("Cadastrar", func() {
+	registerBtn := newButton(T("register.button"), func() {
 		if usernameEntry.Text == "" || fullNameEntry.Text == "" || emailEntry.Text == "" ||
 			passwordEntry.Text == "" || confirmPasswordEntry.Text == "" {
-			dialog.ShowError(fmt.Errorf("Todos os campos são obrigatórios"), w)
+			dialog.ShowError(errors.New(T("common.all_fields_required")), w)
 			return
 		}
 		if passwordEntry.Text != confirmPasswordEntry.Text {
-			dialog.ShowError(fmt.Errorf("As senhas não coincidem"), w)
+			dialog.ShowError(errors.New(T("common.passwords_mismatch")), w)
+			return
+		}
+		if err := validatePasswordStrength(passwordEntry.Text); err != nil {
+			dialog.ShowError(err, w)
 			return
 		}
-		if !strings.Contains(emailEntry.Text, "@") || !strings.Contains(emailEntry.Text, ".") {
-			dialog.ShowError(fmt.Errorf("E-mail inválido"), w)
+		email, err := validateEmail(emailEntry.Text)
+		if err != nil {
+			dialog.ShowError(err, w)
 			return
 		}
 		var existingUser User
 		if err := db.Where("username = ?", usernameEntry.Text).First(&existingUser).Error; err == nil {
-			dialog.ShowError(fmt.Errorf("Nome de usuário já existe"), w)
+			dialog.ShowError(errors.New(T("register.username_exists")), w)
 			return
 		}
-		if err := db.Where("email = ?", emailEntry.Text).First(&existingUser).Error; err == nil {
-			dialog.ShowError(fmt.Errorf("E-mail já registrado"), w)
+		if err := db.Where("email = ?", email).First(&existingUser).Error; err == nil {
+			dialog.ShowError(errors.New(T("register.email_exists")), w)
 			return
 		}
 		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(passwordEntry.Text), bcrypt.DefaultCost)
 		if err != nil {
-			dialog.ShowError(fmt.Errorf("Erro ao criptografar senha: %v", err), w)
+			dialog.ShowError(errors.New(T("common.password_hash_error", err)), w)
 			return
 		}
 		user := User{
 			Username: usernameEntry.Text,
 			FullName: fullNameEntry.Text,
-			Email:    emailEntry.Text,
+			Email:    email,
 			Password: string(hashedPassword),
+			Role:     "user",
 		}
 		if err := db.Create(&user).Error; err != nil {
-			dialog.ShowError(err, w)
+			showDBError(err, w)
 			return
 		}
-		dialog.ShowInformation("Sucesso", "Usuário cadastrado com sucesso!", w)
+		recordAudit(auditCreate, "Usuário", user.ID, user.Username)
+		dialog.ShowInformation(T("common.success"), T("register.success"), w)
 		w.SetContent(loginScreen(w))
 	})
 
-	backBtn := widget.NewButton("Voltar ao Login", func() {
+	backBtn := newButton(T("register.back"), func() {
 		w.SetContent(loginScreen(w))
 	})
 
 	return container.NewVBox(form, registerBtn, backBtn)
 }
 
-func loadProductOptions() ([]string, map[string]uint) {
-	var products []Product
-	db.Find(&products)
-	productsList = products
-	var options []string
-	m := make(map[string]uint)
-	for _, p := range products {
-		opt := fmt.Sprintf("%d: %s (%s)", p.ID, p.Name, p.StandardUnit)
-		options = append(options, opt)
-		m[opt] = p.ID
-	}
-	return options, m
-}
-
-func loadStoreOptions() ([]string, map[string]uint) {
-	var stores []Store
-	db.Find(&stores)
-	storesList = stores
-	var options []string
-	m := make(map[string]uint)
-	for _, s := range stores {
-		opt := fmt.Sprintf("%d: %s - %s - %s", s.ID, s.Name, s.Endereco, s.Telefone)
-		options = append(options, opt)
-		m[opt] = s.ID
-	}
-	return options, m
-}
-
-func updateComboBoxes(productSelect, storeSelect *widget.Select) {
-
-	productOptions, productMap = loadProductOptions()
-	storeOptions, storeMap = loadStoreOptions()
-
-	productSelect.Options = productOptions
-	productSelect.Selected = ""
-	storeSelect.Options = storeOptions
-	storeSelect.Selected = ""
-
-	productSelect.Refresh()
-	storeSelect.Refresh()
-}
+func changePasswordTab(w fyne.Window) fyne.CanvasObject {
+	currentPasswordEntry := newPasswordEntry()
+	newPassword := newPasswordEntry()
+	confirmPasswordEntry := newPasswordEntry()
 
-func productTab(w fyne.Window) fyne.CanvasObject {
-	nameEntry := widget.NewEntry()
-	unitEntry := widget.NewEntry()
 	form := widget.NewForm(
-		widget.NewFormItem("Nome do Produto", nameEntry),
-		widget.NewFormItem("Unidade Padrão (KG/LT/etc)", unitEntry),
+		widget.NewFormItem(T("password.current"), currentPasswordEntry),
+		widget.NewFormItem(T("password.new"), newPassword),
+		widget.NewFormItem(T("password.strength_label"), passwordStrengthIndicator(newPassword)),
+		widget.NewFormItem(T("password.confirm_new"), confirmPasswordEntry),
 	)
-	listData := binding.NewStringList()
-	updateProductList(listData)
 
-	addBtn := widget.NewButton("Adicionar Produto", func() {
-		if nameEntry.Text == "" || unitEntry.Text == "" {
-			dialog.ShowError(fmt.Errorf("Nome e unidade são obrigatórios"), w)
+	saveBtn := newButton(T("password.button"), func() {
+		if currentUser == nil {
+			dialog.ShowError(errors.New(T("password.no_user")), w)
 			return
 		}
-		product := Product{Name: nameEntry.Text, StandardUnit: unitEntry.Text}
-		if err := db.Create(&product).Error; err != nil {
+		if currentPasswordEntry.Text == "" || newPassword.Text == "" || confirmPasswordEntry.Text == "" {
+			dialog.ShowError(errors.New(T("common.all_fields_required")), w)
+			return
+		}
+		var user User
+		if err := db.First(&user, currentUser.ID).Error; err != nil {
+			dialog.ShowError(errors.New(T("common.user_not_found")), w)
+			return
+		}
+		if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(currentPasswordEntry.Text)); err != nil {
+			dialog.ShowError(errors.New(T("password.wrong_current")), w)
+			return
+		}
+		if newPassword.Text != confirmPasswordEntry.Text {
+			dialog.ShowError(errors.New(T("common.passwords_mismatch")), w)
+			return
+		}
+		if err := validatePasswordStrength(newPassword.Text); err != nil {
 			dialog.ShowError(err, w)
 			return
 		}
-		dialog.ShowInformation("Sucesso", "Produto adicionado!", w)
-		nameEntry.SetText("")
-		unitEntry.SetText("")
-		updateProductList(listData)
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword.Text), bcrypt.DefaultCost)
+		if err != nil {
+			dialog.ShowError(errors.New(T("common.password_hash_error", err)), w)
+			return
+		}
+		user.Password = string(hashedPassword)
+		if err := db.Save(&user).Error; err != nil {
+			showDBError(err, w)
+			return
+		}
+		recordAudit(auditUpdate, "Usuário", user.ID, user.Username)
+		setCurrentUser(user)
+		dialog.ShowInformation(T("common.success"), T("password.success"), w)
+		currentPasswordEntry.SetText("")
+		newPassword.SetText("")
+		confirmPasswordEntry.SetText("")
+	})
+
+	return container.NewVBox(form, saveBtn)
+}
+
+func userTab(w fyne.Window) fyne.CanvasObject {
+	listData := binding.NewStringList()
+	updateUserList(listData)
+	registerRefresh(func() (func(), func()) {
+		return listRefresh(listData, &usersList, loadUserList)
 	})
 
-	var selectedProductIndex int = -1
+	var selectedUserID uint
 	list := widget.NewListWithData(listData,
 		func() fyne.CanvasObject {
 			return widget.NewLabel("template")
@@ -307,294 +656,678 @@ func productTab(w fyne.Window) fyne.CanvasObject {
 		},
 	)
 	list.OnSelected = func(id widget.ListItemID) {
-		selectedProductIndex = id
+		if id < len(usersList) {
+			selectedUserID = usersList[id].ID
+		}
 	}
 
-	editBtn := widget.NewButton("Editar Produto Selecionado", func() {
-		if selectedProductIndex < 0 || selectedProductIndex >= len(productsList) {
-			dialog.ShowError(fmt.Errorf("Selecione um produto para editar"), w)
+	editBtn := newButton(T("users.edit"), func() {
+		var user User
+		if selectedUserID == 0 || db.First(&user, selectedUserID).Error != nil {
+			dialog.ShowError(errors.New(T("users.select_to_edit")), w)
 			return
 		}
-		product := productsList[selectedProductIndex]
 
-		nameEdit := widget.NewEntry()
-		nameEdit.SetText(product.Name)
-		unitEdit := widget.NewEntry()
-		unitEdit.SetText(product.StandardUnit)
+		fullNameEdit := newEntry()
+		fullNameEdit.SetText(user.FullName)
+		emailEdit := newEntry()
+		emailEdit.SetText(user.Email)
+		roleEdit := widget.NewSelect(roleOptions, nil)
+		roleEdit.SetSelected(user.Role)
 
 		items := []*widget.FormItem{
-			widget.NewFormItem("Nome do Produto", nameEdit),
-			widget.NewFormItem("Unidade Padrão", unitEdit),
+			widget.NewFormItem(T("register.full_name"), fullNameEdit),
+			widget.NewFormItem(T("register.email"), emailEdit),
+			widget.NewFormItem(T("users.role"), roleEdit),
 		}
-		dlg := dialog.NewForm("Editar Produto", "Salvar", "Cancelar", items, func(ok bool) {
+		dlg := dialog.NewForm(T("users.edit_title"), T("common.save"), T("common.cancel"), items, func(ok bool) {
 			if !ok {
 				return
 			}
-			if nameEdit.Text == "" || unitEdit.Text == "" {
-				dialog.ShowError(fmt.Errorf("Nome e unidade são obrigatórios"), w)
+			if fullNameEdit.Text == "" || emailEdit.Text == "" {
+				dialog.ShowError(errors.New(T("users.name_email_required")), w)
 				return
 			}
-			product.Name = nameEdit.Text
-			product.StandardUnit = unitEdit.Text
-			if err := db.Save(&product).Error; err != nil {
+			email, err := validateEmail(emailEdit.Text)
+			if err != nil {
 				dialog.ShowError(err, w)
 				return
 			}
-			dialog.ShowInformation("Sucesso", "Produto atualizado!", w)
-			updateProductList(listData)
+			var existingUser User
+			if err := db.Where("email = ? AND id <> ?", email, user.ID).First(&existingUser).Error; err == nil {
+				dialog.ShowError(errors.New(T("register.email_exists")), w)
+				return
+			}
+			if user.Role == roleAdmin && roleEdit.Selected != roleAdmin {
+				var adminCount int64
+				db.Model(&User{}).Where("role = ?", roleAdmin).Count(&adminCount)
+				if adminCount <= 1 {
+					dialog.ShowError(errors.New(T("users.last_admin_role")), w)
+					return
+				}
+			}
+			user.FullName = fullNameEdit.Text
+			user.Email = email
+			if roleEdit.Selected != "" {
+				user.Role = roleEdit.Selected
+			}
+			if err := db.Save(&user).Error; err != nil {
+				showDBError(err, w)
+				return
+			}
+			recordAudit(auditUpdate, "Usuário", user.ID, user.Username)
+			if currentUser != nil && currentUser.ID == user.ID {
+				setCurrentUser(user)
+			}
+			dialog.ShowInformation(T("common.success"), T("users.updated"), w)
+			updateUserList(listData)
 		}, w)
 		dlg.Show()
 	})
 
-	deleteBtn := widget.NewButton("Deletar Produto Selecionado", func() {
-		if selectedProductIndex < 0 || selectedProductIndex >= len(productsList) {
-			dialog.ShowError(fmt.Errorf("Selecione um produto para deletar"), w)
+	resetBtn := newButton(T("users.reset_password"), func() {
+		var user User
+		if selectedUserID == 0 || db.First(&user, selectedUserID).Error != nil {
+			dialog.ShowError(errors.New(T("users.select_to_reset")), w)
+			return
+		}
+		dialog.ShowConfirm(T("common.confirmation"), T("users.reset_confirm", user.Username), func(confirm bool) {
+			if !confirm {
+				return
+			}
+			tempPassword, err := generateTempPassword(10)
+			if err != nil {
+				dialog.ShowError(errors.New(T("forgot.temp_error", err)), w)
+				return
+			}
+			hashedPassword, err := bcrypt.GenerateFromPassword([]byte(tempPassword), bcrypt.DefaultCost)
+			if err != nil {
+				dialog.ShowError(errors.New(T("common.password_hash_error", err)), w)
+				return
+			}
+			user.Password = string(hashedPassword)
+			user.FailedAttempts = 0
+			user.LockedUntil = nil
+			if err := db.Save(&user).Error; err != nil {
+				showDBError(err, w)
+				return
+			}
+			recordAudit(auditUpdate, "Usuário", user.ID, user.Username)
+			dialog.ShowInformation(T("forgot.temp_title"), T("users.new_password", user.Username, tempPassword), w)
+			updateUserList(listData)
+		}, w)
+	})
+
+	deleteBtn := newButton(T("users.delete"), func() {
+		var user User
+		if selectedUserID == 0 || db.First(&user, selectedUserID).Error != nil {
+			dialog.ShowError(errors.New(T("users.select_to_delete")), w)
 			return
 		}
-		product := productsList[selectedProductIndex]
-		dialog.ShowConfirm("Confirmação", "Tem certeza que deseja deletar este produto?", func(confirm bool) {
+		if user.Role == roleAdmin {
+			var adminCount int64
+			db.Model(&User{}).Where("role = ?", roleAdmin).Count(&adminCount)
+			if adminCount <= 1 {
+				dialog.ShowError(errors.New(T("users.last_admin_delete")), w)
+				return
+			}
+		}
+		dialog.ShowConfirm(T("common.confirmation"), T("users.confirm_delete", user.Username), func(confirm bool) {
 			if confirm {
-				if err := db.Delete(&product).Error; err != nil {
-					dialog.ShowError(err, w)
+				if err := db.Delete(&user).Error; err != nil {
+					showDBError(err, w)
 					return
 				}
-				dialog.ShowInformation("Sucesso", "Produto deletado!", w)
-				updateProductList(listData)
+				recordAudit(auditDelete, "Usuário", user.ID, user.Username)
+				dialog.ShowInformation(T("common.success"), T("users.deleted"), w)
+				list.UnselectAll()
+				selectedUserID = 0
+				updateUserList(listData)
 			}
 		}, w)
 	})
 
-	return container.NewVBox(form, addBtn, editBtn, deleteBtn, widget.NewLabel("Lista de Produtos:"), list)
+	writeActions(editBtn, resetBtn, deleteBtn)
+	return container.NewVBox(editBtn, resetBtn, deleteBtn, widget.NewLabel(T("users.list")), list)
+}
+
+func updateUserList(data binding.StringList) {
+	var lines []string
+	usersList, lines = loadUserList()
+	data.Set(lines)
+}
+
+func loadUserList() ([]User, []string) {
+	var users []User
+	db.Find(&users)
+	now := time.Now()
+	var strs []string
+	for _, u := range users {
+		line := fmt.Sprintf("%d: %s - %s - %s (%s)", u.ID, u.Username, u.FullName, u.Email, u.Role)
+		if remaining := lockoutRemaining(u, now); remaining > 0 {
+			line += " " + T("users.locked", formatLockout(remaining))
+		}
+		strs = append(strs, line)
+	}
+	return users, strs
+}
+
+func loadProductOptions() ([]string, map[string]uint) {
+	return productCache.get(queryProductOptions)
 }
 
-func updateProductList(data binding.StringList) {
+func queryProductOptions() ([]string, map[string]uint) {
 	var products []Product
 	db.Find(&products)
-	productsList = products
-	var strs []string
+	var options []string
+	m := make(map[string]uint)
 	for _, p := range products {
-		strs = append(strs, fmt.Sprintf("%d: %s (%s)", p.ID, p.Name, p.StandardUnit))
+		opt := fmt.Sprintf("%d: %s (%s)", p.ID, p.Name, p.StandardUnit)
+		options = append(options, opt)
+		m[opt] = p.ID
 	}
-	data.Set(strs)
+	return options, m
 }
 
-func storeTab(w fyne.Window) fyne.CanvasObject {
-	nameEntry := widget.NewEntry()
-	enderecoEntry := widget.NewEntry()
-	telefoneEntry := widget.NewEntry()
-	form := widget.NewForm(
-		widget.NewFormItem("Nome da Loja", nameEntry),
-		widget.NewFormItem("Endereço", enderecoEntry),
-		widget.NewFormItem("Telefone", telefoneEntry),
-	)
-	listData := binding.NewStringList()
-	updateStoreList(listData)
-
-	addBtn := widget.NewButton("Adicionar Loja", func() {
-		if nameEntry.Text == "" || enderecoEntry.Text == "" {
-			dialog.ShowError(fmt.Errorf("Nome e endereço da loja são obrigatórios"), w)
-			return
-		}
-		store := Store{Name: nameEntry.Text, Endereco: enderecoEntry.Text, Telefone: telefoneEntry.Text}
-		if err := db.Create(&store).Error; err != nil {
-			dialog.ShowError(err, w)
-			return
-		}
-		dialog.ShowInformation("Sucesso", "Loja adicionada!", w)
-		nameEntry.SetText("")
-		enderecoEntry.SetText("")
-		telefoneEntry.SetText("")
-		updateStoreList(listData)
-	})
+func loadStoreOptions() ([]string, map[string]uint) {
+	return storeCache.get(queryStoreOptions)
+}
 
-	var selectedStoreIndex int = -1
-	list := widget.NewListWithData(listData,
-		func() fyne.CanvasObject {
-			return widget.NewLabel("template")
+func queryStoreOptions() ([]string, map[string]uint) {
+	var stores []Store
+	db.Preload("Contacts").Find(&stores)
+	var options []string
+	m := make(map[string]uint)
+	for _, s := range stores {
+		opt := fmt.Sprintf("%d: %s - %s - %s", s.ID, s.Name, s.Endereco, primaryPhone(s))
+		options = append(options, opt)
+		m[opt] = s.ID
+	}
+	return options, m
+}
+
+func loadGroupOptions() ([]string, map[string]uint) {
+	var groups []PrescriptionGroup
+	db.Order("date desc").Find(&groups)
+	var options []string
+	m := make(map[string]uint)
+	for _, g := range groups {
+		opt := fmt.Sprintf("%d: %s (%s)", g.ID, g.Name, g.Date.Format("2006-01-02"))
+		options = append(options, opt)
+		m[opt] = g.ID
+	}
+	return options, m
+}
+
+func loadCategoryOptions() []string {
+	var used []string
+	db.Model(&Product{}).Distinct().Pluck("category", &used)
+	options := append([]string{}, defaultCategories...)
+	seen := make(map[string]bool)
+	for _, c := range options {
+		seen[c] = true
+	}
+	for _, c := range used {
+		if c != "" && !seen[c] {
+			seen[c] = true
+			options = append(options, c)
+		}
+	}
+	for i, c := range options {
+		options[i] = categoryLabel(c)
+	}
+	sort.Strings(options)
+	return options
+}
+
+func categoryLabel(category string) string {
+	if category == defaultCategory {
+		return T(defaultCategoryKey)
+	}
+	return category
+}
+
+func categoryFromLabel(label string) string {
+	if label == T(defaultCategoryKey) {
+		return defaultCategory
+	}
+	return label
+}
+
+func newCategoryFilter(onChanged func(string)) *widget.Select {
+	sel := widget.NewSelect(append([]string{T(allCategoriesOption)}, loadCategoryOptions()...), nil)
+	sel.SetSelected(T(allCategoriesOption))
+	sel.OnChanged = onChanged
+	return sel
+}
+
+func selectedCategory(sel *widget.Select) string {
+	if sel.Selected == T(allCategoriesOption) {
+		return ""
+	}
+	return categoryFromLabel(sel.Selected)
+}
+
+func updateComboBoxes(productSelect, storeSelect *widget.Select) {
+
+	productOptions, productMap = loadProductOptions()
+	storeOptions, storeMap = loadStoreOptions()
+
+	productSelect.Options = productOptions
+	productSelect.Selected = ""
+	storeSelect.Options = storeOptions
+	storeSelect.Selected = ""
+
+	productSelect.Refresh()
+	storeSelect.Refresh()
+}
+
+func productTab(w fyne.Window) fyne.CanvasObject {
+	nameEntry := newEntry()
+	limitLength(nameEntry, T("product.name"), maxNameLength)
+	unitSelect := widget.NewSelect(loadUnitOptions(), nil)
+	categorySelect := widget.NewSelect(loadCategoryOptions(), func(s string) {})
+	categorySelect.SetSelected(categoryLabel(defaultCategory))
+	densityEntry := newEntry()
+	densityEntry.SetPlaceHolder(T("product.density_placeholder"))
+	var imagePath string
+	imageField := container.NewStack(imagePickerField(w, &imagePath))
+	form := widget.NewForm(
+		widget.NewFormItem(T("product.name"), nameEntry),
+		widget.NewFormItem(T("product.unit"), unitSelectField(w, unitSelect)),
+		widget.NewFormItem(T("product.category"), categorySelect),
+		widget.NewFormItem(T("product.density"), densityEntry),
+		widget.NewFormItem(T("product.image"), imageField),
+	)
+	searchEntry := newEntry()
+	searchEntry.SetPlaceHolder(T("product.search"))
+	listData := binding.NewStringList()
+	sortSelect := widget.NewSelect(translatedOptions(nameSortOptions), nil)
+	sortSelect.SetSelected(T(sortInsertion))
+	sortOrder := func() string { return optionKey(nameSortOptions, sortSelect.Selected) }
+	categoryFilter := newCategoryFilter(nil)
+	refreshList := func() {
+		updateProductList(listData, searchEntry.Text, selectedCategory(categoryFilter), sortOrder())
+	}
+	refreshList()
+
+	addBtn := newButton(T("product.add"), func() {
+		if !connectionAvailable(w) {
+			return
+		}
+		if nameEntry.Text == "" || unitSelect.Selected == "" {
+			dialog.ShowError(errors.New(T("product.name_unit_required")), w)
+			return
+		}
+		if err := checkLengths(nameEntry); err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		density, err := parseDensity(densityEntry.Text)
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		product := Product{Name: nameEntry.Text, StandardUnit: unitSelect.Selected, Category: categoryFromLabel(categorySelect.Selected), ImagePath: imagePath, Density: density}
+		if err := db.Create(&product).Error; err != nil {
+			showDBError(err, w)
+			return
+		}
+		recordAudit(auditCreate, "Produto", product.ID, product.Name)
+		invalidateProductCache()
+		dialog.ShowInformation(T("common.success"), T("product.added"), w)
+		nameEntry.SetText("")
+		unitSelect.ClearSelected()
+		categorySelect.SetSelected(categoryLabel(defaultCategory))
+		densityEntry.SetText("")
+		imagePath = ""
+		imageField.Objects = []fyne.CanvasObject{imagePickerField(w, &imagePath)}
+		imageField.Refresh()
+		refreshList()
+	})
+
+	var selectedProductID uint
+	list := newSelectionListWithData(listData,
+		func() fyne.CanvasObject {
+			return widget.NewLabel("template")
 		},
 		func(di binding.DataItem, co fyne.CanvasObject) {
 			co.(*widget.Label).Bind(di.(binding.String))
 		},
 	)
 	list.OnSelected = func(id widget.ListItemID) {
-		selectedStoreIndex = id
+		if id < len(productsList) {
+			selectedProductID = productsList[id].ID
+		}
+	}
+	searchEntry.OnChanged = func(text string) {
+		list.UnselectAll()
+		selectedProductID = 0
+		refreshList()
+	}
+	sortSelect.OnChanged = func(string) {
+		refreshList()
+	}
+	categoryFilter.OnChanged = func(string) {
+		list.UnselectAll()
+		selectedProductID = 0
+		refreshList()
 	}
 
-	editBtn := widget.NewButton("Editar Loja Selecionada", func() {
-		if selectedStoreIndex < 0 || selectedStoreIndex >= len(storesList) {
-			dialog.ShowError(fmt.Errorf("Selecione uma loja para editar"), w)
+	editBtn := newButton(T("product.edit"), func() {
+		var product Product
+		if selectedProductID == 0 || db.First(&product, selectedProductID).Error != nil {
+			dialog.ShowError(errors.New(T("product.select_to_edit")), w)
 			return
 		}
-		store := storesList[selectedStoreIndex]
 
-		nameEdit := widget.NewEntry()
-		nameEdit.SetText(store.Name)
-		enderecoEdit := widget.NewEntry()
-		enderecoEdit.SetText(store.Endereco)
-		telefoneEdit := widget.NewEntry()
-		telefoneEdit.SetText(store.Telefone)
+		nameEdit := newEntry()
+		nameEdit.SetText(product.Name)
+		limitLength(nameEdit, T("product.name"), maxNameLength)
+		unitEdit := widget.NewSelect(loadUnitOptions(), nil)
+		unitEdit.SetSelected(normalizeUnit(product.StandardUnit))
+		categoryEdit := widget.NewSelect(loadCategoryOptions(), func(s string) {})
+		categoryEdit.SetSelected(categoryLabel(product.Category))
+		densityEdit := newEntry()
+		densityEdit.SetPlaceHolder(T("product.density_placeholder"))
+		densityEdit.SetText(formatDensity(product))
+		imageEdit := product.ImagePath
 
 		items := []*widget.FormItem{
-			widget.NewFormItem("Nome da Loja", nameEdit),
-			widget.NewFormItem("Endereço", enderecoEdit),
-			widget.NewFormItem("Telefone", telefoneEdit),
+			widget.NewFormItem(T("product.name"), nameEdit),
+			widget.NewFormItem(T("product.unit"), unitSelectField(w, unitEdit)),
+			widget.NewFormItem(T("product.category"), categoryEdit),
+			widget.NewFormItem(T("product.density"), densityEdit),
+			widget.NewFormItem(T("product.image"), imagePickerField(w, &imageEdit)),
 		}
-		dlg := dialog.NewForm("Editar Loja", "Salvar", "Cancelar", items, func(ok bool) {
+		dlg := dialog.NewForm(T("product.edit_title"), T("common.save"), T("common.cancel"), items, func(ok bool) {
 			if !ok {
 				return
 			}
-			if nameEdit.Text == "" || enderecoEdit.Text == "" {
-				dialog.ShowError(fmt.Errorf("Nome e endereço são obrigatórios"), w)
+			if nameEdit.Text == "" || unitEdit.Selected == "" {
+				dialog.ShowError(errors.New(T("product.name_unit_required")), w)
 				return
 			}
-			store.Name = nameEdit.Text
-			store.Endereco = enderecoEdit.Text
-			store.Telefone = telefoneEdit.Text
-			if err := db.Save(&store).Error; err != nil {
+			if err := checkLengths(nameEdit); err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			density, err := parseDensity(densityEdit.Text)
+			if err != nil {
 				dialog.ShowError(err, w)
 				return
 			}
-			dialog.ShowInformation("Sucesso", "Loja atualizada!", w)
-			updateStoreList(listData)
+			original := product
+			product.Name = nameEdit.Text
+			product.StandardUnit = unitEdit.Selected
+			product.Category = categoryFromLabel(categoryEdit.Selected)
+			if product.Category == "" {
+				product.Category = defaultCategory
+			}
+			product.ImagePath = imageEdit
+			product.Density = density
+			if !productChanged(original, product) {
+				showNoChanges(w)
+				return
+			}
+			warning := ""
+			if !sameUnit(product.StandardUnit, original.StandardUnit) {
+				var quoteCount, presCount int64
+				db.Model(&Quote{}).Where("product_id = ?", product.ID).Count(&quoteCount)
+				db.Model(&Prescription{}).Where("product_id = ?", product.ID).Count(&presCount)
+				if quoteCount > 0 || presCount > 0 {
+					warning = T("product.unit_change_warning", original.Name, original.StandardUnit, product.StandardUnit, quoteCount, presCount)
+				}
+			}
+			confirmSave(w, warning, func() {
+				if err := db.Save(&product).Error; err != nil {
+					showDBError(err, w)
+					return
+				}
+				recordAudit(auditUpdate, "Produto", product.ID, product.Name)
+				invalidateProductCache()
+				dialog.ShowInformation(T("common.success"), T("product.updated"), w)
+				refreshList()
+			})
 		}, w)
 		dlg.Show()
 	})
 
-	deleteBtn := widget.NewButton("Deletar Loja Selecionada", func() {
-		if selectedStoreIndex < 0 || selectedStoreIndex >= len(storesList) {
-			dialog.ShowError(fmt.Errorf("Selecione uma loja para deletar"), w)
+	deleteBtn := newButton(T("product.delete"), func() {
+		var product Product
+		if selectedProductID == 0 || db.First(&product, selectedProductID).Error != nil {
+			dialog.ShowError(errors.New(T("product.select_to_delete")), w)
+			return
+		}
+		var quoteCount, presCount int64
+		db.Model(&Quote{}).Where("product_id = ?", product.ID).Count(&quoteCount)
+		db.Model(&Prescription{}).Where("product_id = ?", product.ID).Count(&presCount)
+		if quoteCount > 0 || presCount > 0 {
+			msg := T("product.linked_message", quoteCount, presCount, product.Name)
+			dialog.ShowConfirm(T("product.linked_title"), msg, func(confirm bool) {
+				if !confirm {
+					return
+				}
+				err := db.Transaction(func(tx *gorm.DB) error {
+					if err := tx.Where("product_id = ?", product.ID).Delete(&Quote{}).Error; err != nil {
+						return err
+					}
+					if err := tx.Where("product_id = ?", product.ID).Delete(&Prescription{}).Error; err != nil {
+						return err
+					}
+					return tx.Delete(&product).Error
+				})
+				if err != nil {
+					showDBError(err, w)
+					return
+				}
+				recordAudit(auditDelete, "Produto", product.ID, fmt.Sprintf("%s (com %d cotações e %d receituários)", product.Name, quoteCount, presCount))
+				invalidateProductCache()
+				dialog.ShowInformation(T("common.success"), T("product.linked_deleted"), w)
+				refreshList()
+			}, w)
 			return
 		}
-		store := storesList[selectedStoreIndex]
-		dialog.ShowConfirm("Confirmação", "Tem certeza que deseja deletar esta loja?", func(confirm bool) {
+		dialog.ShowConfirm(T("common.confirmation"), T("product.confirm_delete"), func(confirm bool) {
 			if confirm {
-				if err := db.Delete(&store).Error; err != nil {
-					dialog.ShowError(err, w)
+				if err := db.Delete(&product).Error; err != nil {
+					showDBError(err, w)
 					return
 				}
-				dialog.ShowInformation("Sucesso", "Loja deletada!", w)
-				updateStoreList(listData)
+				recordAudit(auditDelete, "Produto", product.ID, product.Name)
+				invalidateProductCache()
+				dialog.ShowInformation(T("common.success"), T("product.deleted"), w)
+				refreshList()
+			}
+		}, w)
+	})
+
+	exportBtn := newButton(T("common.export_csv"), func() {
+		saveCSV(w, "produtos.csv", productCSVRows())
+	})
+
+	importBtn := newButton(T("common.import_csv"), func() {
+		dialog.ShowFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			if reader == nil {
+				return
+			}
+			defer reader.Close()
+			imported, rejected, err := importProductsCSV(reader)
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			refreshList()
+			productOptions, productMap = loadProductOptions()
+			msg := T("product.imported", imported)
+			if len(rejected) > 0 {
+				msg += T("product.rejected_lines", len(rejected), strings.Join(rejected, "\n"))
 			}
+			dialog.ShowInformation(T("common.import_done"), msg, w)
 		}, w)
 	})
 
-	return container.NewVBox(form, addBtn, editBtn, deleteBtn, widget.NewLabel("Lista de Lojas:"), list)
+	registerRefresh(func() (func(), func()) {
+		filter, category, order := searchEntry.Text, selectedCategory(categoryFilter), sortOrder()
+		return listRefresh(listData, &productsList, func() ([]Product, []string) {
+			return loadProductList(filter, category, order)
+		})
+	})
+	registerRecordFocus("tab.products", func(id uint) {
+		searchEntry.SetText("")
+		categoryFilter.SetSelected(T(allCategoriesOption))
+		refreshList()
+		for i, p := range productsList {
+			if p.ID == id {
+				list.Select(i)
+				list.ScrollTo(i)
+				return
+			}
+		}
+	})
+
+	submitOnEnter(addBtn.OnTapped, nameEntry)
+	registerTabActions("tab.products", tabActions{
+		submit:         addBtn.OnTapped,
+		newRecord:      func() { w.Canvas().Focus(nameEntry) },
+		deleteSelected: deleteBtn.OnTapped,
+	})
+
+	writeActions(addBtn, editBtn, deleteBtn, importBtn)
+	return container.NewVBox(form, addBtn, editBtn, deleteBtn, exportBtn, importBtn, widget.NewLabel(T("product.list")), container.NewBorder(nil, nil, nil, container.NewHBox(categoryFilter, sortSelect), searchEntry), list)
 }
 
-func updateStoreList(data binding.StringList) {
-	var stores []Store
-	db.Find(&stores)
-	storesList = stores
+func updateProductList(data binding.StringList, filter, category, order string) {
+	var lines []string
+	productsList, lines = loadProductList(filter, category, order)
+	data.Set(lines)
+}
+
+func loadProductList(filter, category, order string) ([]Product, []string) {
+	var products []Product
+	query := db.Order("id")
+	if category != "" {
+		query = query.Where("category = ?", category)
+	}
+	query.Find(&products)
+	sortByName(products, func(p Product) string { return p.Name }, order)
+	var matched []Product
 	var strs []string
-	for _, s := range stores {
-		strs = append(strs, fmt.Sprintf("%d: %s - %s - %s", s.ID, s.Name, s.Endereco, s.Telefone))
+	for _, p := range products {
+		if !matchesFilter(p.Name, filter) {
+			continue
+		}
+		matched = append(matched, p)
+		line := fmt.Sprintf("%d: %s (%s) [%s]", p.ID, p.Name, p.StandardUnit, categoryLabel(p.Category))
+		if d := formatDensity(p); d != "" {
+			line += T("product.density_suffix", d)
+		}
+		strs = append(strs, line)
 	}
-	data.Set(strs)
+	return matched, strs
 }
 
-func quoteTab(w fyne.Window) fyne.CanvasObject {
-	productSelect := widget.NewSelect(productOptions, func(s string) {})
-	storeSelect := widget.NewSelect(storeOptions, func(s string) {})
-	priceEntry := widget.NewEntry()
-	packSizeEntry := widget.NewEntry()
-	packUnitEntry := widget.NewEntry()
-	convFactorEntry := widget.NewEntry()
-	convFactorEntry.SetText("1.0")
-	dateEntry := widget.NewEntry()
+func matchesFilter(value, filter string) bool {
+	filter = strings.TrimSpace(filter)
+	if filter == "" {
+		return true
+	}
+	return strings.Contains(foldText(value), foldText(filter))
+}
 
+func truncateText(value string, max int) string {
+	value = strings.Join(strings.Fields(value), " ")
+	runes := []rune(value)
+	if len(runes) <= max {
+		return value
+	}
+	return string(runes[:max]) + "..."
+}
+
+func storeTab(w fyne.Window) fyne.CanvasObject {
+	nameEntry := newEntry()
+	enderecoEntry := newEntry()
+	telefoneEntry := newEntry()
+	applyPhoneMask(telefoneEntry)
+	cnpjEntry := newEntry()
+	cnpjEntry.SetPlaceHolder(T("store.cnpj_placeholder"))
+	representativeEntry := newEntry()
+	representativeEntry.SetPlaceHolder(T("store.representative_placeholder"))
+	limitLength(nameEntry, T("quickadd.store_name_field"), maxNameLength)
+	limitLength(enderecoEntry, T("quickadd.address"), maxAddressLength)
+	limitLength(representativeEntry, T("store.representative"), maxNameLength)
+	preferredCheck := widget.NewCheck(T("store.preferred"), nil)
 	form := widget.NewForm(
-		widget.NewFormItem("Produto", productSelect),
-		widget.NewFormItem("Loja", storeSelect),
-		widget.NewFormItem("Preço por Embalagem (R$)", priceEntry),
-		widget.NewFormItem("Tamanho da Embalagem", packSizeEntry),
-		widget.NewFormItem("Unidade da Embalagem", packUnitEntry),
-		widget.NewFormItem("Fator de Conversão Manual", convFactorEntry),
-		widget.NewFormItem("Data (YYYY-MM-DD)", dateEntry),
+		widget.NewFormItem(T("quickadd.store_name"), nameEntry),
+		widget.NewFormItem(T("quickadd.address"), enderecoEntry),
+		widget.NewFormItem(T("quickadd.main_phone"), telefoneEntry),
+		widget.NewFormItem("CNPJ", cnpjEntry),
+		widget.NewFormItem(T("store.representative"), representativeEntry),
+		widget.NewFormItem("", preferredCheck),
 	)
+	searchEntry := newEntry()
+	searchEntry.SetPlaceHolder(T("store.search"))
 	listData := binding.NewStringList()
-	updateQuoteList(listData)
+	sortSelect := widget.NewSelect(translatedOptions(nameSortOptions), nil)
+	sortSelect.SetSelected(T(sortInsertion))
+	sortOrder := func() string { return optionKey(nameSortOptions, sortSelect.Selected) }
+	updateStoreList(listData, "", sortOrder())
 
-	addBtn := widget.NewButton("Adicionar Cotação", func() {
-		selectedProduct := productSelect.Selected
-		if selectedProduct == "" {
-			dialog.ShowError(fmt.Errorf("Selecione um produto"), w)
-			return
-		}
-		productID, ok := productMap[selectedProduct]
-		if !ok {
-			dialog.ShowError(fmt.Errorf("Produto inválido"), w)
-			return
-		}
-		selectedStore := storeSelect.Selected
-		if selectedStore == "" {
-			dialog.ShowError(fmt.Errorf("Selecione uma loja"), w)
+	addBtn := newButton(T("store.add"), func() {
+		if !connectionAvailable(w) {
 			return
 		}
-		storeID, ok := storeMap[selectedStore]
-		if !ok {
-			dialog.ShowError(fmt.Errorf("Loja inválida"), w)
+		if nameEntry.Text == "" || enderecoEntry.Text == "" {
+			dialog.ShowError(errors.New(T("quickadd.store_required")), w)
 			return
 		}
-		price, err := strconv.ParseFloat(priceEntry.Text, 64)
-		if err != nil {
-			dialog.ShowError(fmt.Errorf("Preço inválido"), w)
+		if err := checkLengths(nameEntry, enderecoEntry, representativeEntry); err != nil {
+			dialog.ShowError(err, w)
 			return
 		}
-		packSize, err := strconv.ParseFloat(packSizeEntry.Text, 64)
+		telefone, err := validatePhone(telefoneEntry.Text)
 		if err != nil {
-			dialog.ShowError(fmt.Errorf("Tamanho da embalagem inválido"), w)
+			dialog.ShowError(err, w)
 			return
 		}
-		convFactor, err := strconv.ParseFloat(convFactorEntry.Text, 64)
+		cnpj, err := parseOptionalCNPJ(cnpjEntry.Text)
 		if err != nil {
-			dialog.ShowError(fmt.Errorf("Fator de conversão inválido"), w)
-			return
-		}
-		if packUnitEntry.Text == "" {
-			dialog.ShowError(fmt.Errorf("Unidade da embalagem é obrigatória"), w)
-			return
-		}
-		dateStr := dateEntry.Text
-		if dateStr == "" {
-			dialog.ShowError(fmt.Errorf("Data é obrigatória"), w)
+			dialog.ShowError(err, w)
 			return
 		}
-		t, err := time.Parse("2006-01-02", dateStr)
-		if err != nil {
-			dialog.ShowError(fmt.Errorf("Formato de data inválido (use YYYY-MM-DD)"), w)
-			return
+		if cnpj != nil {
+			var existing Store
+			if err := db.Where("cnpj = ?", *cnpj).First(&existing).Error; err == nil {
+				dialog.ShowError(errors.New(T("store.cnpj_exists", existing.Name)), w)
+				return
+			}
 		}
-		quote := Quote{
-			ProductID:        productID,
-			StoreID:          storeID,
-			Price:            price,
-			PackagingSize:    packSize,
-			PackagingUnit:    packUnitEntry.Text,
-			ConversionFactor: convFactor,
-			Date:             t,
+		store := Store{Name: nameEntry.Text, Endereco: enderecoEntry.Text, CNPJ: cnpj, Representative: strings.TrimSpace(representativeEntry.Text), Preferred: preferredCheck.Checked}
+		if telefone != "" {
+			store.Contacts = []StoreContact{{Type: contactPrincipal, Phone: telefone}}
 		}
-		if err := db.Create(&quote).Error; err != nil {
-			dialog.ShowError(err, w)
+		if err := db.Create(&store).Error; err != nil {
+			showDBError(err, w)
 			return
 		}
-		dialog.ShowInformation("Sucesso", "Cotação adicionada!", w)
-		productSelect.ClearSelected()
-		storeSelect.ClearSelected()
-		priceEntry.SetText("")
-		packSizeEntry.SetText("")
-		packUnitEntry.SetText("")
-		convFactorEntry.SetText("1.0")
-		dateEntry.SetText("")
-		updateQuoteList(listData)
-		updateComboBoxes(productSelect, storeSelect)
-	})
-
-	refreshBtn := widget.NewButton("Atualizar Listas de Produtos e Lojas", func() {
-		updateComboBoxes(productSelect, storeSelect)
+		recordAudit(auditCreate, "Loja", store.ID, store.Name)
+		invalidateStoreCache()
+		dialog.ShowInformation(T("common.success"), T("store.added"), w)
+		nameEntry.SetText("")
+		enderecoEntry.SetText("")
+		telefoneEntry.SetText("")
+		cnpjEntry.SetText("")
+		representativeEntry.SetText("")
+		preferredCheck.SetChecked(false)
+		updateStoreList(listData, searchEntry.Text, sortOrder())
 	})
 
-	var selectedQuoteIndex int = -1
-	list := widget.NewListWithData(listData,
+	var selectedStoreID uint
+	list := newSelectionListWithData(listData,
 		func() fyne.CanvasObject {
 			return widget.NewLabel("template")
 		},
@@ -603,494 +1336,2357 @@ func quoteTab(w fyne.Window) fyne.CanvasObject {
 		},
 	)
 	list.OnSelected = func(id widget.ListItemID) {
-		selectedQuoteIndex = id
+		if id < len(storesList) {
+			selectedStoreID = storesList[id].ID
+		}
+	}
+	searchEntry.OnChanged = func(text string) {
+		list.UnselectAll()
+		selectedStoreID = 0
+		updateStoreList(listData, text, sortOrder())
+	}
+	sortSelect.OnChanged = func(string) {
+		updateStoreList(listData, searchEntry.Text, sortOrder())
 	}
 
-	editBtn := widget.NewButton("Editar Cotação Selecionada", func() {
-		if selectedQuoteIndex < 0 || selectedQuoteIndex >= len(quotesList) {
-			dialog.ShowError(fmt.Errorf("Selecione uma cotação para editar"), w)
+	editBtn := newButton(T("store.edit"), func() {
+		var store Store
+		if selectedStoreID == 0 || db.First(&store, selectedStoreID).Error != nil {
+			dialog.ShowError(errors.New(T("store.select_to_edit")), w)
 			return
 		}
-		quote := quotesList[selectedQuoteIndex]
-
-		updateComboBoxes(productSelect, storeSelect)
 
-		productSelectEdit := widget.NewSelect(productOptions, func(s string) {})
-		for opt, id := range productMap {
-			if id == quote.ProductID {
-				productSelectEdit.SetSelected(opt)
-				break
-			}
-		}
-		storeSelectEdit := widget.NewSelect(storeOptions, func(s string) {})
-		for opt, id := range storeMap {
-			if id == quote.StoreID {
-				storeSelectEdit.SetSelected(opt)
-				break
-			}
-		}
-		priceEdit := widget.NewEntry()
-		priceEdit.SetText(fmt.Sprintf("%.2f", quote.Price))
-		packSizeEdit := widget.NewEntry()
-		packSizeEdit.SetText(fmt.Sprintf("%.2f", quote.PackagingSize))
-		packUnitEdit := widget.NewEntry()
-		packUnitEdit.SetText(quote.PackagingUnit)
-		convFactorEdit := widget.NewEntry()
-		convFactorEdit.SetText(fmt.Sprintf("%.2f", quote.ConversionFactor))
-		dateEdit := widget.NewEntry()
-		dateEdit.SetText(quote.Date.Format("2006-01-02"))
+		nameEdit := newEntry()
+		nameEdit.SetText(store.Name)
+		enderecoEdit := newEntry()
+		enderecoEdit.SetText(store.Endereco)
+		cnpjEdit := newEntry()
+		cnpjEdit.SetText(displayCNPJ(store.CNPJ))
+		representativeEdit := newEntry()
+		representativeEdit.SetText(store.Representative)
+		limitLength(nameEdit, T("quickadd.store_name_field"), maxNameLength)
+		limitLength(enderecoEdit, T("quickadd.address"), maxAddressLength)
+		limitLength(representativeEdit, T("store.representative"), maxNameLength)
+		preferredEdit := widget.NewCheck(T("store.preferred"), nil)
+		preferredEdit.SetChecked(store.Preferred)
 
 		items := []*widget.FormItem{
-			widget.NewFormItem("Produto", productSelectEdit),
-			widget.NewFormItem("Loja", storeSelectEdit),
-			widget.NewFormItem("Preço por Embalagem (R$)", priceEdit),
-			widget.NewFormItem("Tamanho da Embalagem", packSizeEdit),
-			widget.NewFormItem("Unidade da Embalagem", packUnitEdit),
-			widget.NewFormItem("Fator de Conversão Manual", convFactorEdit),
-			widget.NewFormItem("Data (YYYY-MM-DD)", dateEdit),
-		}
-		dlg := dialog.NewForm("Editar Cotação", "Salvar", "Cancelar", items, func(ok bool) {
-			if !ok {
-				return
-			}
-			selectedProduct := productSelectEdit.Selected
-			if selectedProduct == "" {
-				dialog.ShowError(fmt.Errorf("Selecione um produto"), w)
-				return
-			}
-			productID, ok := productMap[selectedProduct]
+			widget.NewFormItem(T("quickadd.store_name"), nameEdit),
+			widget.NewFormItem(T("quickadd.address"), enderecoEdit),
+			widget.NewFormItem("CNPJ", cnpjEdit),
+			widget.NewFormItem(T("store.representative"), representativeEdit),
+			widget.NewFormItem("", preferredEdit),
+		}
+		dlg := dialog.NewForm(T("store.edit_title"), T("common.save"), T("common.cancel"), items, func(ok bool) {
 			if !ok {
-				dialog.ShowError(fmt.Errorf("Produto inválido"), w)
-				return
-			}
-			selectedStore := storeSelectEdit.Selected
-			if selectedStore == "" {
-				dialog.ShowError(fmt.Errorf("Selecione uma loja"), w)
 				return
 			}
-			storeID, ok := storeMap[selectedStore]
-			if !ok {
-				dialog.ShowError(fmt.Errorf("Loja inválida"), w)
+			if nameEdit.Text == "" || enderecoEdit.Text == "" {
+				dialog.ShowError(errors.New(T("store.name_address_required")), w)
 				return
 			}
-			price, err := strconv.ParseFloat(priceEdit.Text, 64)
-			if err != nil {
-				dialog.ShowError(fmt.Errorf("Preço inválido"), w)
+			if err := checkLengths(nameEdit, enderecoEdit, representativeEdit); err != nil {
+				dialog.ShowError(err, w)
 				return
 			}
-			packSize, err := strconv.ParseFloat(packSizeEdit.Text, 64)
+			original := store
+			store.Name = nameEdit.Text
+			cnpj, err := parseOptionalCNPJ(cnpjEdit.Text)
 			if err != nil {
-				dialog.ShowError(fmt.Errorf("Tamanho da embalagem inválido"), w)
+				dialog.ShowError(err, w)
 				return
 			}
-			convFactor, err := strconv.ParseFloat(convFactorEdit.Text, 64)
-			if err != nil {
-				dialog.ShowError(fmt.Errorf("Fator de conversão inválido"), w)
-				return
+			if cnpj != nil {
+				var existing Store
+				if err := db.Where("cnpj = ? AND id <> ?", *cnpj, store.ID).First(&existing).Error; err == nil {
+					dialog.ShowError(errors.New(T("store.cnpj_exists", existing.Name)), w)
+					return
+				}
 			}
-			if packUnitEdit.Text == "" {
-				dialog.ShowError(fmt.Errorf("Unidade da embalagem é obrigatória"), w)
+			store.Endereco = enderecoEdit.Text
+			store.CNPJ = cnpj
+			store.Representative = strings.TrimSpace(representativeEdit.Text)
+			store.Preferred = preferredEdit.Checked
+			if !storeChanged(original, store) {
+				showNoChanges(w)
 				return
 			}
-			dateStr := dateEdit.Text
-			if dateStr == "" {
-				dialog.ShowError(fmt.Errorf("Data é obrigatória"), w)
+			if err := db.Save(&store).Error; err != nil {
+				showDBError(err, w)
 				return
 			}
-			t, err := time.Parse("2006-01-02", dateStr)
-			if err != nil {
-				dialog.ShowError(fmt.Errorf("Formato de data inválido (use YYYY-MM-DD)"), w)
-				return
-			}
-			quote.ProductID = productID
-			quote.StoreID = storeID
-			quote.Price = price
-			quote.PackagingSize = packSize
-			quote.PackagingUnit = packUnitEdit.Text
-			quote.ConversionFactor = convFactor
-			quote.Date = t
-			if err := db.Save(&quote).Error; err != nil {
-				dialog.ShowError(err, w)
-				return
-			}
-			dialog.ShowInformation("Sucesso", "Cotação atualizada!", w)
-			updateQuoteList(listData)
-			updateComboBoxes(productSelect, storeSelect)
+			recordAudit(auditUpdate, "Loja", store.ID, store.Name)
+			invalidateStoreCache()
+			dialog.ShowInformation(T("common.success"), T("store.updated"), w)
+			updateStoreList(listData, searchEntry.Text, sortOrder())
 		}, w)
 		dlg.Show()
 	})
 
-	deleteBtn := widget.NewButton("Deletar Cotação Selecionada", func() {
-		if selectedQuoteIndex < 0 || selectedQuoteIndex >= len(quotesList) {
-			dialog.ShowError(fmt.Errorf("Selecione uma cotação para deletar"), w)
+	deleteBtn := newButton(T("store.delete"), func() {
+		var store Store
+		if selectedStoreID == 0 || db.First(&store, selectedStoreID).Error != nil {
+			dialog.ShowError(errors.New(T("store.select_to_delete")), w)
+			return
+		}
+		var quoteCount int64
+		db.Model(&Quote{}).Where("store_id = ?", store.ID).Count(&quoteCount)
+		if quoteCount > 0 {
+			msg := T("store.linked_message", quoteCount, store.Name)
+			dialog.ShowConfirm(T("product.linked_title"), msg, func(confirm bool) {
+				if !confirm {
+					return
+				}
+				err := db.Transaction(func(tx *gorm.DB) error {
+					if err := tx.Where("store_id = ?", store.ID).Delete(&Quote{}).Error; err != nil {
+						return err
+					}
+					return tx.Delete(&store).Error
+				})
+				if err != nil {
+					showDBError(err, w)
+					return
+				}
+				recordAudit(auditDelete, "Loja", store.ID, fmt.Sprintf("%s (com %d cotações)", store.Name, quoteCount))
+				invalidateStoreCache()
+				dialog.ShowInformation(T("common.success"), T("store.linked_deleted"), w)
+				updateStoreList(listData, searchEntry.Text, sortOrder())
+			}, w)
 			return
 		}
-		quote := quotesList[selectedQuoteIndex]
-		dialog.ShowConfirm("Confirmação", "Tem certeza que deseja deletar esta cotação?", func(confirm bool) {
+		dialog.ShowConfirm(T("common.confirmation"), T("store.confirm_delete"), func(confirm bool) {
 			if confirm {
-				if err := db.Delete(&quote).Error; err != nil {
-					dialog.ShowError(err, w)
+				if err := db.Delete(&store).Error; err != nil {
+					showDBError(err, w)
 					return
 				}
-				dialog.ShowInformation("Sucesso", "Cotação deletada!", w)
-				updateQuoteList(listData)
-				updateComboBoxes(productSelect, storeSelect)
+				recordAudit(auditDelete, "Loja", store.ID, store.Name)
+				invalidateStoreCache()
+				dialog.ShowInformation(T("common.success"), T("store.deleted"), w)
+				updateStoreList(listData, searchEntry.Text, sortOrder())
 			}
 		}, w)
 	})
 
-	return container.NewVBox(form, addBtn, refreshBtn, editBtn, deleteBtn, widget.NewLabel("Lista de Cotações:"), list)
+	contactsBtn := newButton(T("store.contacts"), func() {
+		var store Store
+		if selectedStoreID == 0 || db.First(&store, selectedStoreID).Error != nil {
+			dialog.ShowError(errors.New(T("store.select_for_contacts")), w)
+			return
+		}
+		showStoreContacts(w, store, func() {
+			invalidateStoreCache()
+			updateStoreList(listData, searchEntry.Text, sortOrder())
+		})
+	})
+
+	exportBtn := newButton(T("common.export_csv"), func() {
+		saveCSV(w, "lojas.csv", storeCSVRows())
+	})
+
+	registerRefresh(func() (func(), func()) {
+		filter, order := searchEntry.Text, sortOrder()
+		return listRefresh(listData, &storesList, func() ([]Store, []string) {
+			return loadStoreList(filter, order)
+		})
+	})
+	registerRecordFocus("tab.stores", func(id uint) {
+		searchEntry.SetText("")
+		updateStoreList(listData, "", sortOrder())
+		for i, s := range storesList {
+			if s.ID == id {
+				list.Select(i)
+				list.ScrollTo(i)
+				return
+			}
+		}
+	})
+
+	submitOnEnter(addBtn.OnTapped, nameEntry, enderecoEntry, telefoneEntry, cnpjEntry)
+	registerTabActions("tab.stores", tabActions{
+		submit:         addBtn.OnTapped,
+		newRecord:      func() { w.Canvas().Focus(nameEntry) },
+		deleteSelected: deleteBtn.OnTapped,
+	})
+
+	writeActions(addBtn, editBtn, deleteBtn)
+	return container.NewVBox(form, addBtn, editBtn, contactsBtn, deleteBtn, exportBtn, widget.NewLabel(T("store.list")), container.NewBorder(nil, nil, nil, sortSelect, searchEntry), list)
 }
 
-func updateQuoteList(data binding.StringList) {
-	var quotes []Quote
-	db.Preload("Product").Preload("Store").Find(&quotes)
-	quotesList = quotes
+func updateStoreList(data binding.StringList, filter, order string) {
+	var lines []string
+	storesList, lines = loadStoreList(filter, order)
+	data.Set(lines)
+}
+
+func loadStoreList(filter, order string) ([]Store, []string) {
+	var stores []Store
+	db.Preload("Contacts").Order("id").Find(&stores)
+	sortByName(stores, func(s Store) string { return s.Name }, order)
+	var matched []Store
 	var strs []string
-	for _, q := range quotes {
-		strs = append(strs, fmt.Sprintf("ID: %d, Prod: %s, Loja: %s, Preço: %.2f, Tam: %.2f %s, Conv: %.2f, Data: %s",
-			q.ID, q.Product.Name, q.Store.Name, q.Price, q.PackagingSize, q.PackagingUnit, q.ConversionFactor, q.Date.Format("2006-01-02")))
+	for _, s := range stores {
+		if !matchesFilter(s.Name, filter) {
+			continue
+		}
+		matched = append(matched, s)
+		line := fmt.Sprintf("%d: %s - %s - %s", s.ID, s.Name, s.Endereco, primaryPhone(s))
+		if s.Preferred {
+			line = T("store.preferred_mark") + " " + line
+		}
+		if s.CNPJ != nil {
+			line += " - CNPJ " + displayCNPJ(s.CNPJ)
+		}
+		if s.Representative != "" {
+			line += " - " + T("store.representative_short", s.Representative)
+		}
+		strs = append(strs, line)
 	}
-	data.Set(strs)
+	return matched, strs
 }
 
-func prescriptionTab(w fyne.Window) fyne.CanvasObject {
+func quoteTab(w fyne.Window) fyne.CanvasObject {
 	productSelect := widget.NewSelect(productOptions, func(s string) {})
-	reqQtyEntry := widget.NewEntry()
-	reqUnitEntry := widget.NewEntry()
+	storeSelect := widget.NewSelect(storeOptions, func(s string) {})
+	priceEntry := newEntry()
+	currencySelect := widget.NewSelect(currencyOptions, func(s string) {})
+	currencySelect.SetSelected(defaultCurrency)
+	packSizeEntry := newEntry()
+	packUnitEntry := newEntry()
+	convFactorEntry := newEntry()
+	convFactorEntry.SetText("1.0")
+	minOrderEntry := newEntry()
+	minOrderEntry.SetPlaceHolder(T("quote.min_order_placeholder"))
+	shippingEntry := newEntry()
+	shippingModeSelect := widget.NewSelect(translatedOptions(shippingModes), nil)
+	deliveryEntry := newEntry()
+	deliveryEntry.SetPlaceHolder(T("quote.delivery_placeholder"))
+	datePicker := NewDatePicker()
+	validPicker := NewDatePicker()
+	notesEntry := newMultiLineEntry()
+	notesEntry.SetPlaceHolder(T("quote.notes_placeholder"))
+
+	fillConvFactor := func() {
+		productID, ok := productMap[productSelect.Selected]
+		if !ok {
+			return
+		}
+		if factor, found := autoConversionFactor(productID, packUnitEntry.Text); found {
+			convFactorEntry.SetText(formatFloat(factor))
+		}
+	}
+	productSelect.OnChanged = func(string) { fillConvFactor() }
+	packUnitEntry.OnChanged = func(string) { fillConvFactor() }
+
+	reloadCombos := func(productID, storeID uint) {
+		updateComboBoxes(productSelect, storeSelect)
+		selectOptionByID(productSelect, productMap, productID)
+		selectOptionByID(storeSelect, storeMap, storeID)
+	}
+	newProduct := func() {
+		storeID := storeMap[storeSelect.Selected]
+		quickAddProduct(w, func(p Product) { reloadCombos(p.ID, storeID) })
+	}
+	newStore := func() {
+		productID := productMap[productSelect.Selected]
+		quickAddStore(w, func(s Store) { reloadCombos(productID, s.ID) })
+	}
 
 	form := widget.NewForm(
-		widget.NewFormItem("Produto", productSelect),
-		widget.NewFormItem("Quantidade Requerida", reqQtyEntry),
-		widget.NewFormItem("Unidade Requerida", reqUnitEntry),
+		widget.NewFormItem(T("compare.product"), withQuickAdd(productSelect, "+ "+T("quickadd.product_title"), newProduct)),
+		widget.NewFormItem(T("compare.store"), withQuickAdd(storeSelect, "+ "+T("quickadd.store_title"), newStore)),
+		widget.NewFormItem(T("quote.price"), priceEntry),
+		widget.NewFormItem(T("quote.currency"), currencySelect),
+		widget.NewFormItem(T("quote.pack_size"), packSizeEntry),
+		widget.NewFormItem(T("quote.pack_unit"), packUnitEntry),
+		widget.NewFormItem(T("quote.conv_factor"), convFactorEntry),
+		widget.NewFormItem(T("quote.min_order"), minOrderEntry),
+		widget.NewFormItem(T("quote.shipping"), shippingField(shippingEntry, shippingModeSelect)),
+		widget.NewFormItem(T("quote.delivery_days"), deliveryEntry),
+		widget.NewFormItem(T("compare.date"), datePicker),
+		widget.NewFormItem(T("quote.valid_until"), optionalDateField(validPicker)),
+		widget.NewFormItem(T("quote.notes"), notesEntry),
 	)
 	listData := binding.NewStringList()
-	updatePrescriptionList(listData)
+	page := 0
+	pageSize := defaultQuotePageSize
+	pageLabel := widget.NewLabel("")
+	sortSelect := widget.NewSelect(translatedOptions(quoteSortOptions), nil)
+	sortSelect.SetSelected(T(sortInsertion))
+	sortOrder := func() string { return optionKey(quoteSortOptions, sortSelect.Selected) }
+	categoryFilter := newCategoryFilter(nil)
+	storeFilter := widget.NewSelect(append([]string{allStoresOption}, storeOptions...), nil)
+	storeFilter.SetSelected(allStoresOption)
+	productSearch := newEntry()
+	productSearch.SetPlaceHolder(T("quote.search"))
+	totalLabel := widget.NewLabel("")
+	currentFilter := func() quoteListFilter {
+		return quoteListFilter{
+			category:    selectedCategory(categoryFilter),
+			storeID:     storeMap[storeFilter.Selected],
+			productName: productSearch.Text,
+		}
+	}
+	showQuotePage := func(result quotePage, filter quoteListFilter) {
+		quotesList = result.quotes
+		page = result.page
+		listData.Set(result.lines)
+		pageLabel.SetText(T("quote.page", page+1, result.totalPages))
+		if filter.storeID != 0 {
+			totalLabel.SetText(T("quote.store_total", result.total))
+		} else {
+			totalLabel.SetText(T("quote.total", result.total))
+		}
+	}
+	refreshQuotes := func() {
+		filter := currentFilter()
+		showQuotePage(loadQuotePage(page, pageSize, filter, sortOrder()), filter)
+	}
+	refreshQuotes()
 
-	addBtn := widget.NewButton("Adicionar Receituário", func() {
+	addBtn := newButton(T("quote.add"), func() {
+		if !connectionAvailable(w) {
+			return
+		}
 		selectedProduct := productSelect.Selected
 		if selectedProduct == "" {
-			dialog.ShowError(fmt.Errorf("Selecione um produto"), w)
+			dialog.ShowError(errors.New(T("common.select_product")), w)
 			return
 		}
 		productID, ok := productMap[selectedProduct]
 		if !ok {
-			dialog.ShowError(fmt.Errorf("Produto inválido"), w)
+			dialog.ShowError(errors.New(T("quote.invalid_product")), w)
 			return
 		}
-		reqQty, err := strconv.ParseFloat(reqQtyEntry.Text, 64)
+		selectedStore := storeSelect.Selected
+		if selectedStore == "" {
+			dialog.ShowError(errors.New(T("quote.select_store")), w)
+			return
+		}
+		storeID, ok := storeMap[selectedStore]
+		if !ok {
+			dialog.ShowError(errors.New(T("quote.invalid_store")), w)
+			return
+		}
+		price, err := strconv.ParseFloat(priceEntry.Text, 64)
 		if err != nil {
-			dialog.ShowError(fmt.Errorf("Quantidade inválida"), w)
+			dialog.ShowError(errors.New(T("quote.invalid_price")), w)
 			return
 		}
-		if reqUnitEntry.Text == "" {
-			dialog.ShowError(fmt.Errorf("Unidade requerida é obrigatória"), w)
+		if price <= 0 {
+			dialog.ShowError(errors.New(T("quote.price_positive")), w)
 			return
 		}
-		var product Product
-		if err := db.First(&product, productID).Error; err != nil {
-			dialog.ShowError(fmt.Errorf("Produto não encontrado"), w)
+		packSize, err := strconv.ParseFloat(packSizeEntry.Text, 64)
+		if err != nil {
+			dialog.ShowError(errors.New(T("quote.invalid_pack_size")), w)
 			return
 		}
-		if reqUnitEntry.Text != product.StandardUnit {
-			dialog.ShowError(fmt.Errorf("Unidade requerida '%s' não compatível com unidade padrão '%s'", reqUnitEntry.Text, product.StandardUnit), w)
+		if packSize <= 0 {
+			dialog.ShowError(errors.New(T("quote.pack_size_positive")), w)
 			return
 		}
-		pres := Prescription{
-			ProductID:        productID,
-			RequiredQuantity: reqQty,
-			RequiredUnit:     reqUnitEntry.Text,
+		convFactor, err := strconv.ParseFloat(convFactorEntry.Text, 64)
+		if err != nil {
+			dialog.ShowError(errors.New(T("quote.invalid_conv_factor")), w)
+			return
 		}
-		if err := db.Create(&pres).Error; err != nil {
+		if convFactor <= 0 {
+			dialog.ShowError(errors.New(T("quote.conv_factor_positive")), w)
+			return
+		}
+		if factor, found := autoConversionFactor(productID, packUnitEntry.Text); found {
+			convFactor = factor
+		}
+		if packUnitEntry.Text == "" {
+			dialog.ShowError(errors.New(T("quote.pack_unit_required")), w)
+			return
+		}
+		minOrder, err := parseMinOrderQuantity(minOrderEntry.Text)
+		if err != nil {
 			dialog.ShowError(err, w)
 			return
 		}
-		dialog.ShowInformation("Sucesso", "Receituário adicionado!", w)
-		productSelect.ClearSelected()
-		reqQtyEntry.SetText("")
-		reqUnitEntry.SetText("")
-		updatePrescriptionList(listData)
-		productOptions, productMap = loadProductOptions()
-		productSelect.Options = productOptions
-		productSelect.Refresh()
+		shipping, err := parseShippingCost(shippingEntry.Text)
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		deliveryDays, err := parseDeliveryDays(deliveryEntry.Text)
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		t, ok := datePicker.Date()
+		if !ok {
+			dialog.ShowError(errors.New(T("quote.date_required")), w)
+			return
+		}
+		if err := validateQuoteDate(t); err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		quote := Quote{
+			ProductID:        productID,
+			StoreID:          storeID,
+			Price:            price,
+			Currency:         currencySelect.Selected,
+			PackagingSize:    packSize,
+			PackagingUnit:    packUnitEntry.Text,
+			ConversionFactor: convFactor,
+			Date:             t,
+			Notes:            strings.TrimSpace(notesEntry.Text),
+			MinOrderQuantity: minOrder,
+			ShippingCost:     shipping,
+			ShippingPerUnit:  optionKey(shippingModes, shippingModeSelect.Selected) == shippingPerUnit,
+			DeliveryDays:     deliveryDays,
+		}
+		if validUntil, ok := validPicker.Date(); ok {
+			if validUntil.Before(t) {
+				dialog.ShowError(errors.New(T("quote.valid_before_date")), w)
+				return
+			}
+			quote.ValidUntil = &validUntil
+		}
+		if currentUser != nil {
+			quote.UserID = &currentUser.ID
+		}
+		persist := func(replaced *Quote) {
+			if err := quoteRepo.Create(&quote, replaced); err != nil {
+				showDBError(err, w)
+				return
+			}
+			auditSavedQuote(auditCreate, quote, replaced)
+			dialog.ShowInformation(T("common.success"), T("quote.added"), w)
+			productSelect.ClearSelected()
+			storeSelect.ClearSelected()
+			priceEntry.SetText("")
+			currencySelect.SetSelected(defaultCurrency)
+			packSizeEntry.SetText("")
+			packUnitEntry.SetText("")
+			convFactorEntry.SetText("1.0")
+			minOrderEntry.SetText("")
+			shippingEntry.SetText("")
+			shippingModeSelect.SetSelected(T(shippingPerOrder))
+			deliveryEntry.SetText("")
+			datePicker.Clear()
+			validPicker.Clear()
+			notesEntry.SetText("")
+			refreshQuotes()
+			updateComboBoxes(productSelect, storeSelect)
+		}
+		warnings := []string{packagingUnitWarning(productID, quote.PackagingUnit, convFactor), quoteDateWarning(t)}
+		confirmEach(w, warnings, func() {
+			if existing, found := quoteRepo.FindDuplicate(quote); found {
+				askDuplicateQuote(w, existing, persist)
+				return
+			}
+			persist(nil)
+		})
 	})
 
-	refreshBtn := widget.NewButton("Atualizar Lista de Produtos", func() {
-		productOptions, productMap = loadProductOptions()
-		productSelect.Options = productOptions
-		productSelect.Refresh()
+	refreshBtn := newButton(T("quotesearch.refresh_lists"), func() {
+		updateComboBoxes(productSelect, storeSelect)
+		storeFilter.Options = append([]string{allStoresOption}, storeOptions...)
+		storeFilter.SetSelected(allStoresOption)
+	})
+	registerRefresh(func() (func(), func()) {
+		productID, storeID := productMap[productSelect.Selected], storeMap[storeSelect.Selected]
+		filter, order, current := currentFilter(), sortOrder(), page
+		var result quotePage
+		return func() {
+				result = loadQuotePage(current, pageSize, filter, order)
+			}, func() {
+				reloadCombos(productID, storeID)
+				storeFilter.Options = append([]string{allStoresOption}, storeOptions...)
+				storeFilter.Refresh()
+				showQuotePage(result, filter)
+			}
 	})
 
-	var selectedPrescriptionIndex int = -1
-	list := widget.NewListWithData(listData,
+	var selectedQuoteID uint
+	compared := make(map[uint]bool)
+	list := newSelectionList(
+		func() int {
+			return listData.Length()
+		},
 		func() fyne.CanvasObject {
-			return widget.NewLabel("template")
+			return container.NewBorder(nil, nil, widget.NewCheck("", nil), nil, widget.NewLabel("template"))
 		},
-		func(di binding.DataItem, co fyne.CanvasObject) {
-			co.(*widget.Label).Bind(di.(binding.String))
+		func(id widget.ListItemID, co fyne.CanvasObject) {
+			row := co.(*fyne.Container)
+			label := row.Objects[0].(*widget.Label)
+			check := row.Objects[1].(*widget.Check)
+			text, _ := listData.GetValue(id)
+			label.SetText(text)
+			if id >= len(quotesList) {
+				return
+			}
+			quoteID := quotesList[id].ID
+			check.OnChanged = nil
+			check.SetChecked(compared[quoteID])
+			check.OnChanged = func(on bool) {
+				if on {
+					compared[quoteID] = true
+				} else {
+					delete(compared, quoteID)
+				}
+			}
 		},
 	)
+	listData.AddListener(binding.NewDataListener(list.Refresh))
 	list.OnSelected = func(id widget.ListItemID) {
-		selectedPrescriptionIndex = id
+		if id < len(quotesList) {
+			selectedQuoteID = quotesList[id].ID
+		}
 	}
 
-	editBtn := widget.NewButton("Editar Receituário Selecionado", func() {
-		if selectedPrescriptionIndex < 0 || selectedPrescriptionIndex >= len(prescriptionsList) {
-			dialog.ShowError(fmt.Errorf("Selecione um receituário para editar"), w)
+	editBtn := newButton(T("quote.edit"), func() {
+		quote, err := quoteRepo.FindByID(selectedQuoteID)
+		if selectedQuoteID == 0 || err != nil {
+			dialog.ShowError(errors.New(T("quote.select_to_edit")), w)
 			return
 		}
-		pres := prescriptionsList[selectedPrescriptionIndex]
 
-		productOptions, productMap = loadProductOptions()
+		updateComboBoxes(productSelect, storeSelect)
 
 		productSelectEdit := widget.NewSelect(productOptions, func(s string) {})
 		for opt, id := range productMap {
-			if id == pres.ProductID {
+			if id == quote.ProductID {
 				productSelectEdit.SetSelected(opt)
 				break
 			}
 		}
-		reqQtyEdit := widget.NewEntry()
-		reqQtyEdit.SetText(fmt.Sprintf("%.2f", pres.RequiredQuantity))
-		reqUnitEdit := widget.NewEntry()
-		reqUnitEdit.SetText(pres.RequiredUnit)
+		storeSelectEdit := widget.NewSelect(storeOptions, func(s string) {})
+		for opt, id := range storeMap {
+			if id == quote.StoreID {
+				storeSelectEdit.SetSelected(opt)
+				break
+			}
+		}
+		priceEdit := newEntry()
+		priceEdit.SetText(fmt.Sprintf("%.2f", quote.Price))
+		currencyEdit := widget.NewSelect(currencyOptions, func(s string) {})
+		currencyEdit.SetSelected(quote.Currency)
+		if currencyEdit.Selected == "" {
+			currencyEdit.SetSelected(defaultCurrency)
+		}
+		packSizeEdit := newEntry()
+		packSizeEdit.SetText(fmt.Sprintf("%.2f", quote.PackagingSize))
+		packUnitEdit := newEntry()
+		packUnitEdit.SetText(quote.PackagingUnit)
+		convFactorEdit := newEntry()
+		convFactorEdit.SetText(fmt.Sprintf("%.2f", quote.ConversionFactor))
+		minOrderEdit := newEntry()
+		minOrderEdit.SetPlaceHolder(T("quote.min_order_placeholder"))
+		if quote.MinOrderQuantity > 0 {
+			minOrderEdit.SetText(formatFloat(quote.MinOrderQuantity))
+		}
+		shippingEdit := newEntry()
+		if quote.ShippingCost > 0 {
+			shippingEdit.SetText(formatFloat(quote.ShippingCost))
+		}
+		shippingModeEdit := widget.NewSelect(translatedOptions(shippingModes), nil)
+		shippingModeEdit.SetSelected(T(shippingMode(quote)))
+		deliveryEdit := newEntry()
+		deliveryEdit.SetPlaceHolder(T("quote.delivery_placeholder"))
+		deliveryEdit.SetText(deliveryDaysText(quote))
+		dateEdit := NewDatePicker()
+		dateEdit.SetDate(quote.Date)
+		validEdit := NewDatePicker()
+		if quote.ValidUntil != nil {
+			validEdit.SetDate(*quote.ValidUntil)
+		}
+		notesEdit := newMultiLineEntry()
+		notesEdit.SetText(quote.Notes)
+
+		fillConvFactorEdit := func() {
+			productID, ok := productMap[productSelectEdit.Selected]
+			if !ok {
+				return
+			}
+			if factor, found := autoConversionFactor(productID, packUnitEdit.Text); found {
+				convFactorEdit.SetText(formatFloat(factor))
+			}
+		}
+		productSelectEdit.OnChanged = func(string) { fillConvFactorEdit() }
+		packUnitEdit.OnChanged = func(string) { fillConvFactorEdit() }
 
 		items := []*widget.FormItem{
-			widget.NewFormItem("Produto", productSelectEdit),
-			widget.NewFormItem("Quantidade Requerida", reqQtyEdit),
-			widget.NewFormItem("Unidade Requerida", reqUnitEdit),
-		}
-		dlg := dialog.NewForm("Editar Receituário", "Salvar", "Cancelar", items, func(ok bool) {
+			widget.NewFormItem(T("compare.product"), productSelectEdit),
+			widget.NewFormItem(T("compare.store"), storeSelectEdit),
+			widget.NewFormItem(T("quote.price"), priceEdit),
+			widget.NewFormItem(T("quote.currency"), currencyEdit),
+			widget.NewFormItem(T("quote.pack_size"), packSizeEdit),
+			widget.NewFormItem(T("quote.pack_unit"), packUnitEdit),
+			widget.NewFormItem(T("quote.conv_factor"), convFactorEdit),
+			widget.NewFormItem(T("quote.min_order"), minOrderEdit),
+			widget.NewFormItem(T("quote.shipping"), shippingField(shippingEdit, shippingModeEdit)),
+			widget.NewFormItem(T("quote.delivery_days"), deliveryEdit),
+			widget.NewFormItem(T("compare.date"), dateEdit),
+			widget.NewFormItem(T("quote.valid_until"), optionalDateField(validEdit)),
+			widget.NewFormItem(T("quote.notes"), notesEdit),
+		}
+		dlg := dialog.NewForm(T("quote.edit_title"), T("common.save"), T("common.cancel"), items, func(ok bool) {
 			if !ok {
 				return
 			}
 			selectedProduct := productSelectEdit.Selected
 			if selectedProduct == "" {
-				dialog.ShowError(fmt.Errorf("Selecione um produto"), w)
+				dialog.ShowError(errors.New(T("common.select_product")), w)
 				return
 			}
 			productID, ok := productMap[selectedProduct]
 			if !ok {
-				dialog.ShowError(fmt.Errorf("Produto inválido"), w)
+				dialog.ShowError(errors.New(T("quote.invalid_product")), w)
 				return
 			}
-			reqQty, err := strconv.ParseFloat(reqQtyEdit.Text, 64)
+			selectedStore := storeSelectEdit.Selected
+			if selectedStore == "" {
+				dialog.ShowError(errors.New(T("quote.select_store")), w)
+				return
+			}
+			storeID, ok := storeMap[selectedStore]
+			if !ok {
+				dialog.ShowError(errors.New(T("quote.invalid_store")), w)
+				return
+			}
+			price, err := strconv.ParseFloat(priceEdit.Text, 64)
 			if err != nil {
-				dialog.ShowError(fmt.Errorf("Quantidade inválida"), w)
+				dialog.ShowError(errors.New(T("quote.invalid_price")), w)
 				return
 			}
-			if reqUnitEdit.Text == "" {
-				dialog.ShowError(fmt.Errorf("Unidade requerida é obrigatória"), w)
+			if price <= 0 {
+				dialog.ShowError(errors.New(T("quote.price_positive")), w)
 				return
 			}
-			var product Product
-			if err := db.First(&product, productID).Error; err != nil {
-				dialog.ShowError(fmt.Errorf("Produto não encontrado"), w)
+			packSize, err := strconv.ParseFloat(packSizeEdit.Text, 64)
+			if err != nil {
+				dialog.ShowError(errors.New(T("quote.invalid_pack_size")), w)
 				return
 			}
-			if reqUnitEdit.Text != product.StandardUnit {
-				dialog.ShowError(fmt.Errorf("Unidade requerida '%s' não compatível com unidade padrão '%s'", reqUnitEdit.Text, product.StandardUnit), w)
+			if packSize <= 0 {
+				dialog.ShowError(errors.New(T("quote.pack_size_positive")), w)
 				return
 			}
-			pres.ProductID = productID
-			pres.RequiredQuantity = reqQty
-			pres.RequiredUnit = reqUnitEdit.Text
-			if err := db.Save(&pres).Error; err != nil {
+			convFactor, err := strconv.ParseFloat(convFactorEdit.Text, 64)
+			if err != nil {
+				dialog.ShowError(errors.New(T("quote.invalid_conv_factor")), w)
+				return
+			}
+			if convFactor <= 0 {
+				dialog.ShowError(errors.New(T("quote.conv_factor_positive")), w)
+				return
+			}
+			if factor, found := autoConversionFactor(productID, packUnitEdit.Text); found {
+				convFactor = factor
+			}
+			if packUnitEdit.Text == "" {
+				dialog.ShowError(errors.New(T("quote.pack_unit_required")), w)
+				return
+			}
+			minOrder, err := parseMinOrderQuantity(minOrderEdit.Text)
+			if err != nil {
 				dialog.ShowError(err, w)
 				return
 			}
-			dialog.ShowInformation("Sucesso", "Receituário atualizado!", w)
-			updatePrescriptionList(listData)
-			productOptions, productMap = loadProductOptions()
-			productSelect.Options = productOptions
-			productSelect.Refresh()
+			shipping, err := parseShippingCost(shippingEdit.Text)
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			deliveryDays, err := parseDeliveryDays(deliveryEdit.Text)
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			t, ok := dateEdit.Date()
+			if !ok {
+				dialog.ShowError(errors.New(T("quote.date_required")), w)
+				return
+			}
+			if err := validateQuoteDate(t); err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			original := quote
+			quote.ProductID = productID
+			quote.StoreID = storeID
+			quote.Price = price
+			quote.Currency = currencyEdit.Selected
+			quote.PackagingSize = packSize
+			quote.PackagingUnit = packUnitEdit.Text
+			quote.ConversionFactor = convFactor
+			quote.Date = t
+			quote.Notes = strings.TrimSpace(notesEdit.Text)
+			quote.MinOrderQuantity = minOrder
+			quote.ShippingCost = shipping
+			quote.ShippingPerUnit = optionKey(shippingModes, shippingModeEdit.Selected) == shippingPerUnit
+			quote.DeliveryDays = deliveryDays
+			quote.ValidUntil = nil
+			if validUntil, ok := validEdit.Date(); ok {
+				if validUntil.Before(t) {
+					dialog.ShowError(errors.New(T("quote.valid_before_date")), w)
+					return
+				}
+				quote.ValidUntil = &validUntil
+			}
+			if !quoteChanged(original, quote) {
+				showNoChanges(w)
+				return
+			}
+			warning := ""
+			if quote.ProductID != original.ProductID || quote.StoreID != original.StoreID {
+				warning = T("quote.changed_reference")
+			} else if !quote.Date.Equal(original.Date) {
+				warning = quoteDateWarning(t)
+			}
+			unitWarning := ""
+			if quote.ProductID != original.ProductID || !sameUnit(quote.PackagingUnit, original.PackagingUnit) {
+				unitWarning = packagingUnitWarning(quote.ProductID, quote.PackagingUnit, quote.ConversionFactor)
+			}
+			confirmEach(w, []string{unitWarning, warning}, func() {
+				persist := func(replaced *Quote) {
+					if err := quoteRepo.Update(&quote, replaced); err != nil {
+						showDBError(err, w)
+						return
+					}
+					auditSavedQuote(auditUpdate, quote, replaced)
+					dialog.ShowInformation(T("common.success"), T("quote.updated"), w)
+					refreshQuotes()
+					updateComboBoxes(productSelect, storeSelect)
+				}
+				if existing, found := quoteRepo.FindDuplicate(quote); found {
+					askDuplicateQuote(w, existing, persist)
+					return
+				}
+				persist(nil)
+			})
 		}, w)
 		dlg.Show()
 	})
 
-	deleteBtn := widget.NewButton("Deletar Receituário Selecionado", func() {
-		if selectedPrescriptionIndex < 0 || selectedPrescriptionIndex >= len(prescriptionsList) {
-			dialog.ShowError(fmt.Errorf("Selecione um receituário para deletar"), w)
+	deleteBtn := newButton(T("quote.delete"), func() {
+		quote, err := quoteRepo.FindByID(selectedQuoteID)
+		if selectedQuoteID == 0 || err != nil {
+			dialog.ShowError(errors.New(T("quote.select_to_delete")), w)
 			return
 		}
-		pres := prescriptionsList[selectedPrescriptionIndex]
-		dialog.ShowConfirm("Confirmação", "Tem certeza que deseja deletar este receituário?", func(confirm bool) {
+		dialog.ShowConfirm(T("common.confirmation"), T("quote.confirm_delete"), func(confirm bool) {
 			if confirm {
-				if err := db.Delete(&pres).Error; err != nil {
-					dialog.ShowError(err, w)
+				if err := quoteRepo.Delete(&quote); err != nil {
+					showDBError(err, w)
 					return
 				}
-				dialog.ShowInformation("Sucesso", "Receituário deletado!", w)
-				updatePrescriptionList(listData)
-				productOptions, productMap = loadProductOptions()
-				productSelect.Options = productOptions
-				productSelect.Refresh()
+				recordAudit(auditDelete, "Cotação", quote.ID, "")
+				dialog.ShowInformation(T("common.success"), T("quote.deleted"), w)
+				refreshQuotes()
+				updateComboBoxes(productSelect, storeSelect)
 			}
 		}, w)
 	})
 
-	return container.NewVBox(form, addBtn, refreshBtn, editBtn, deleteBtn, widget.NewLabel("Lista de Receituários:"), list)
-}
+	duplicateBtn := newButton(T("quote.duplicate"), func() {
+		quote, err := quoteRepo.FindByID(selectedQuoteID)
+		if selectedQuoteID == 0 || err != nil {
+			dialog.ShowError(errors.New(T("quote.select_to_duplicate")), w)
+			return
+		}
+		if db.First(&Product{}, quote.ProductID).Error != nil {
+			dialog.ShowError(errors.New(T("quote.product_gone")), w)
+			return
+		}
+		if db.First(&Store{}, quote.StoreID).Error != nil {
+			dialog.ShowError(errors.New(T("quote.store_gone")), w)
+			return
+		}
 
-func updatePrescriptionList(data binding.StringList) {
-	var pres []Prescription
-	db.Preload("Product").Find(&pres)
-	prescriptionsList = pres
-	var strs []string
-	for _, p := range pres {
-		strs = append(strs, fmt.Sprintf("%d: %s - %.2f %s", p.ID, p.Product.Name, p.RequiredQuantity, p.RequiredUnit))
+		updateComboBoxes(productSelect, storeSelect)
+		for opt, id := range productMap {
+			if id == quote.ProductID {
+				productSelect.SetSelected(opt)
+				break
+			}
+		}
+		for opt, id := range storeMap {
+			if id == quote.StoreID {
+				storeSelect.SetSelected(opt)
+				break
+			}
+		}
+		priceEntry.SetText(formatFloat(quote.Price))
+		currencySelect.SetSelected(quote.Currency)
+		packSizeEntry.SetText(formatFloat(quote.PackagingSize))
+		packUnitEntry.SetText(quote.PackagingUnit)
+		convFactorEntry.SetText(formatFloat(quote.ConversionFactor))
+		minOrderEntry.SetText("")
+		if quote.MinOrderQuantity > 0 {
+			minOrderEntry.SetText(formatFloat(quote.MinOrderQuantity))
+		}
+		shippingEntry.SetText("")
+		if quote.ShippingCost > 0 {
+			shippingEntry.SetText(formatFloat(quote.ShippingCost))
+		}
+		shippingModeSelect.SetSelected(T(shippingMode(quote)))
+		deliveryEntry.SetText(deliveryDaysText(quote))
+		datePicker.SetDate(today())
+		validPicker.Clear()
+		if quote.ValidUntil != nil && !quote.ValidUntil.Before(today()) {
+			validPicker.SetDate(*quote.ValidUntil)
+		}
+		notesEntry.SetText(quote.Notes)
+		w.Canvas().Focus(priceEntry)
+	})
+
+	tiersBtn := newButton(T("quote.tiers"), func() {
+		var quote Quote
+		if selectedQuoteID == 0 || db.Preload("Product").First(&quote, selectedQuoteID).Error != nil {
+			dialog.ShowError(errors.New(T("quote.select_for_tiers")), w)
+			return
+		}
+		showPriceTiers(w, quote, refreshQuotes)
+	})
+
+	compareBtn := newButton(T("quote.compare"), func() {
+		var ids []uint
+		for id := range compared {
+			ids = append(ids, id)
+		}
+		showQuoteComparison(w, ids)
+	})
+	clearCompareBtn := newButton(T("quote.clear_compare"), func() {
+		compared = make(map[uint]bool)
+		list.Refresh()
+	})
+
+	exportBtn := newButton(T("common.export_csv"), func() {
+		var rows [][]string
+		runWithProgress(w, T("quote.loading"), func() {
+			rows = quoteCSVRows()
+		}, func() {
+			saveCSV(w, "cotacoes.csv", rows)
+		})
+	})
+
+	prevBtn := newButton(T("quote.previous"), func() {
+		if page > 0 {
+			page--
+			refreshQuotes()
+		}
+	})
+	nextBtn := newButton(T("quote.next"), func() {
+		page++
+		refreshQuotes()
+	})
+	pageSizeSelect := widget.NewSelect([]string{"20", "50", "100"}, func(v string) {
+		size, err := strconv.Atoi(v)
+		if err != nil || size == pageSize {
+			return
+		}
+		pageSize = size
+		page = 0
+		refreshQuotes()
+	})
+	pageSizeSelect.SetSelected(strconv.Itoa(pageSize))
+	sortSelect.OnChanged = func(string) {
+		page = 0
+		refreshQuotes()
+	}
+	categoryFilter.OnChanged = func(string) {
+		page = 0
+		refreshQuotes()
+	}
+	storeFilter.OnChanged = func(string) {
+		page = 0
+		refreshQuotes()
+	}
+	productSearch.OnChanged = func(string) {
+		page = 0
+		refreshQuotes()
 	}
-	data.Set(strs)
+	pagination := container.NewHBox(prevBtn, pageLabel, nextBtn, layout.NewSpacer(), widget.NewLabel(T("quote.category_filter")), categoryFilter, widget.NewLabel(T("quote.sort")), sortSelect, widget.NewLabel(T("quote.page_size")), pageSizeSelect)
+	filters := container.NewBorder(nil, nil, container.NewHBox(widget.NewLabel(T("quote.store_filter")), storeFilter), totalLabel, productSearch)
+
+	submitOnEnter(addBtn.OnTapped, priceEntry, packSizeEntry, packUnitEntry, convFactorEntry, minOrderEntry)
+	registerTabActions("tab.quotes", tabActions{
+		submit:         addBtn.OnTapped,
+		newRecord:      func() { w.Canvas().Focus(productSelect) },
+		deleteSelected: deleteBtn.OnTapped,
+	})
+
+	writeActions(addBtn, editBtn, duplicateBtn, deleteBtn)
+	return container.NewVBox(form, addBtn, refreshBtn, editBtn, duplicateBtn, tiersBtn, deleteBtn, exportBtn, widget.NewLabel(T("quote.list")), filters, pagination, container.NewHBox(compareBtn, clearCompareBtn), list)
+}
+
+type quoteListFilter struct {
+	category    string
+	storeID     uint
+	productName string
+}
+
+type quotePage struct {
+	quotes     []Quote
+	lines      []string
+	page       int
+	totalPages int
+	total      int64
+}
+
+func loadQuotePage(page, pageSize int, filter quoteListFilter, order string) quotePage {
+	result := queryQuotePage(page, pageSize, filter, order)
+	if result.page >= result.totalPages {
+		result = queryQuotePage(result.totalPages-1, pageSize, filter, order)
+	}
+	return result
+}
+
+// Mesma busca sem acentos da busca global: o LIKE só restringe os candidatos e
+// a comparação final é feita em Go, antes da paginação no banco.
+func productIDsMatching(term string) []uint {
+	var products []Product
+	db.Unscoped().Select("id", "name").Where(fmt.Sprintf(likeClause, "name"), likeCandidatePattern(term)).Find(&products)
+	ids := []uint{}
+	for _, p := range products {
+		if searchMatches(p.Name, term) {
+			ids = append(ids, p.ID)
+		}
+	}
+	return ids
+}
+
+func queryQuotePage(page, pageSize int, filter quoteListFilter, order string) quotePage {
+	search := strings.TrimSpace(filter.productName)
+	var productIDs []uint
+	if search != "" {
+		productIDs = productIDsMatching(search)
+	}
+	filtered := func() *gorm.DB {
+		query := db.Model(&Quote{})
+		if filter.storeID != 0 {
+			query = query.Where("quotes.store_id = ?", filter.storeID)
+		}
+		if filter.category != "" {
+			query = query.Joins("JOIN products ON products.id = quotes.product_id").
+				Where("products.category = ?", filter.category)
+		}
+		if search != "" {
+			query = query.Where("quotes.product_id IN ?", productIDs)
+		}
+		return query
+	}
+	var total int64
+	filtered().Count(&total)
+	totalPages := int((total + int64(pageSize) - 1) / int64(pageSize))
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	var quotes []Quote
+	query := filtered().Preload("Product").Preload("Store").Preload("User").Preload("Tiers")
+	if isUnitPriceSort(order) {
+		query = query.Clauses(unitPriceOrder(order == sortUnitPriceDesc))
+	} else {
+		query = query.Order(quoteOrderClause(order))
+	}
+	query.Limit(pageSize).Offset(page * pageSize).Find(&quotes)
+	best := bestQuoteIDs(quotes)
+	ref := today()
+	var strs []string
+	for _, q := range quotes {
+		createdBy := "-"
+		if q.User.ID != 0 {
+			createdBy = q.User.FullName
+		}
+		unitPrice := "N/A"
+		if ppu, ok := pricePerStandardUnit(q); ok {
+			unitPrice = fmt.Sprintf("%.2f", ppu)
+		}
+		prefix := ""
+		if best[q.ID] {
+			prefix = T("quote.best_mark") + " "
+		}
+		if quoteExpired(q, ref) {
+			prefix += T("quote.expired_mark") + " "
+		}
+		line := prefix + T("quote.line",
+			q.ID, q.Product.Name, q.Store.Name, formatQuotePrice(q), q.PackagingSize, q.PackagingUnit, q.ConversionFactor, q.Product.StandardUnit, unitPrice, q.Date.Format("2006-01-02"), createdBy)
+		if q.MinOrderQuantity > 0 {
+			line += T("quote.line_min_order", q.MinOrderQuantity, q.Product.StandardUnit)
+		}
+		if q.ShippingCost > 0 {
+			line += T("quote.line_shipping", formatShipping(q))
+		}
+		if q.DeliveryDays != nil {
+			line += T("quote.line_delivery", formatDeliveryDays(q))
+		}
+		if len(q.Tiers) > 0 {
+			line += T("quote.line_tiers", len(q.Tiers))
+		}
+		if q.Notes != "" {
+			line += T("quote.line_notes", truncateText(q.Notes, 40))
+		}
+		strs = append(strs, line)
+	}
+	return quotePage{quotes: quotes, lines: strs, page: page, totalPages: totalPages, total: total}
+}
+
+func askDuplicateQuote(w fyne.Window, existing Quote, save func(replaced *Quote)) {
+	msg := T("quote.duplicate_message",
+		existing.ID, existing.Product.Name, existing.Store.Name, existing.Date.Format("2006-01-02"), formatQuotePrice(existing))
+	var dlg dialog.Dialog
+	replaceBtn := newButton(T("quote.replace"), func() {
+		dlg.Hide()
+		save(&existing)
+	})
+	replaceBtn.Importance = widget.HighImportance
+	keepBtn := newButton(T("quote.keep_both"), func() {
+		dlg.Hide()
+		save(nil)
+	})
+	cancelBtn := newButton(T("common.cancel"), func() {
+		dlg.Hide()
+	})
+	content := container.NewVBox(widget.NewLabel(msg), container.NewHBox(layout.NewSpacer(), cancelBtn, keepBtn, replaceBtn))
+	dlg = dialog.NewCustomWithoutButtons(T("quote.duplicate_title"), content, w)
+	dlg.Show()
+}
+
+func auditSavedQuote(action string, quote Quote, replaced *Quote) {
+	if replaced != nil {
+		recordAudit(auditDelete, "Cotação", replaced.ID, fmt.Sprintf("substituída pela cotação %d", quote.ID))
+	}
+	recordAudit(action, "Cotação", quote.ID, "")
+}
+
+func bestQuoteIDs(quotes []Quote) map[uint]bool {
+	best := make(map[uint]bool)
+	if len(quotes) == 0 {
+		return best
+	}
+	var productIDs []uint
+	seen := make(map[uint]bool)
+	for _, q := range quotes {
+		if !seen[q.ProductID] {
+			seen[q.ProductID] = true
+			productIDs = append(productIDs, q.ProductID)
+		}
+	}
+
+	var all []Quote
+	db.Where("product_id IN ?", productIDs).Order("id").Find(&all)
+	type groupKey struct {
+		productID uint
+		date      string
+	}
+	bestPrice := make(map[groupKey]float64)
+	bestID := make(map[groupKey]uint)
+	for _, q := range all {
+		ppu, ok := pricePerStandardUnit(q)
+		if !ok {
+			continue
+		}
+		key := groupKey{q.ProductID, q.Date.Format("2006-01-02")}
+		if price, found := bestPrice[key]; !found || ppu < price {
+			bestPrice[key] = ppu
+			bestID[key] = q.ID
+		}
+	}
+	for _, id := range bestID {
+		best[id] = true
+	}
+	return best
+}
+
+func prescriptionTab(w fyne.Window) fyne.CanvasObject {
+	groupSelect := widget.NewSelect(groupOptions, func(s string) {})
+	productSelect := widget.NewSelect(productOptions, func(s string) {})
+	reqQtyEntry := newEntry()
+	reqUnitEntry := newEntry()
+	limitLength(reqUnitEntry, T("prescription.required_unit_field"), maxUnitLength)
+	seasonStartPicker := NewDatePicker()
+	seasonEndPicker := NewDatePicker()
+
+	newGroupBtn := newButton(T("prescription.new_group"), func() {
+		nameEntry := newEntry()
+		limitLength(nameEntry, T("prescription.group_name_field"), maxNameLength)
+		datePicker := NewDatePicker()
+		datePicker.SetDate(time.Now())
+		items := []*widget.FormItem{
+			widget.NewFormItem(T("prescription.group_name"), nameEntry),
+			widget.NewFormItem(T("compare.date"), datePicker),
+		}
+		dlg := dialog.NewForm(T("prescription.new_group"), T("common.save"), T("common.cancel"), items, func(ok bool) {
+			if !ok {
+				return
+			}
+			if nameEntry.Text == "" {
+				dialog.ShowError(errors.New(T("prescription.group_name_required")), w)
+				return
+			}
+			if err := checkLengths(nameEntry); err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			t, ok := datePicker.Date()
+			if !ok {
+				dialog.ShowError(errors.New(T("quote.date_required")), w)
+				return
+			}
+			group := PrescriptionGroup{Name: nameEntry.Text, Date: t}
+			if err := db.Create(&group).Error; err != nil {
+				showDBError(err, w)
+				return
+			}
+			recordAudit(auditCreate, "Receita", group.ID, group.Name)
+			groupOptions, groupMap = loadGroupOptions()
+			groupSelect.Options = groupOptions
+			for opt, id := range groupMap {
+				if id == group.ID {
+					groupSelect.SetSelected(opt)
+					break
+				}
+			}
+			groupSelect.Refresh()
+			dialog.ShowInformation(T("common.success"), T("prescription.group_created"), w)
+		}, w)
+		dlg.Show()
+	})
+
+	form := widget.NewForm(
+		widget.NewFormItem(T("prescription.group"), container.NewBorder(nil, nil, nil, newGroupBtn, groupSelect)),
+		widget.NewFormItem(T("compare.product"), productSelect),
+		widget.NewFormItem(T("prescription.required_quantity"), reqQtyEntry),
+		widget.NewFormItem(T("prescription.required_unit"), reqUnitEntry),
+		widget.NewFormItem(T("prescription.season_start"), seasonStartPicker),
+		widget.NewFormItem(T("prescription.season_end"), seasonEndPicker),
+	)
+	listData := binding.NewStringList()
+	sortSelect := widget.NewSelect(translatedOptions(prescriptionSortOptions), nil)
+	sortSelect.SetSelected(T(sortInsertion))
+	sortOrder := func() string { return optionKey(prescriptionSortOptions, sortSelect.Selected) }
+	updatePrescriptionList(listData, sortOrder())
+
+	addBtn := newButton(T("prescription.add"), func() {
+		if !connectionAvailable(w) {
+			return
+		}
+		groupID, ok := groupMap[groupSelect.Selected]
+		if !ok {
+			dialog.ShowError(errors.New(T("prescription.select_group")), w)
+			return
+		}
+		selectedProduct := productSelect.Selected
+		if selectedProduct == "" {
+			dialog.ShowError(errors.New(T("common.select_product")), w)
+			return
+		}
+		productID, ok := productMap[selectedProduct]
+		if !ok {
+			dialog.ShowError(errors.New(T("quote.invalid_product")), w)
+			return
+		}
+		reqQty, err := strconv.ParseFloat(reqQtyEntry.Text, 64)
+		if err != nil {
+			dialog.ShowError(errors.New(T("prescription.invalid_quantity")), w)
+			return
+		}
+		if reqQty <= 0 {
+			dialog.ShowError(errors.New(T("prescription.quantity_positive")), w)
+			return
+		}
+		if reqUnitEntry.Text == "" {
+			dialog.ShowError(errors.New(T("prescription.unit_required")), w)
+			return
+		}
+		if err := checkLengths(reqUnitEntry); err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		var product Product
+		if err := db.First(&product, productID).Error; err != nil {
+			dialog.ShowError(errors.New(T("common.product_not_found")), w)
+			return
+		}
+		if !sameUnit(reqUnitEntry.Text, product.StandardUnit) {
+			if _, err := convertForProduct(reqQty, reqUnitEntry.Text, product); err != nil {
+				dialog.ShowError(errors.New(T("prescription.incompatible_unit", reqUnitEntry.Text, product.StandardUnit, err)), w)
+				return
+			}
+		}
+		seasonStart, seasonEnd, err := readSeason(seasonStartPicker, seasonEndPicker)
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		pres := Prescription{
+			ProductID:        productID,
+			RequiredQuantity: reqQty,
+			RequiredUnit:     reqUnitEntry.Text,
+			GroupID:          groupID,
+			SeasonStart:      seasonStart,
+			SeasonEnd:        seasonEnd,
+		}
+		if err := db.Create(&pres).Error; err != nil {
+			showDBError(err, w)
+			return
+		}
+		recordAudit(auditCreate, "Receituário", pres.ID, "")
+		dialog.ShowInformation(T("common.success"), T("prescription.added"), w)
+		productSelect.ClearSelected()
+		reqQtyEntry.SetText("")
+		reqUnitEntry.SetText("")
+		seasonStartPicker.Clear()
+		seasonEndPicker.Clear()
+		updatePrescriptionList(listData, sortOrder())
+		productOptions, productMap = loadProductOptions()
+		productSelect.Options = productOptions
+		productSelect.Refresh()
+	})
+
+	refreshBtn := newButton(T("history.refresh_products"), func() {
+		productOptions, productMap = loadProductOptions()
+		productSelect.Options = productOptions
+		productSelect.Refresh()
+		groupOptions, groupMap = loadGroupOptions()
+		groupSelect.Options = groupOptions
+		groupSelect.Refresh()
+	})
+	registerRefresh(func() (func(), func()) {
+		order := sortOrder()
+		load, showList := listRefresh(listData, &prescriptionsList, func() ([]Prescription, []string) {
+			return loadPrescriptionList(order)
+		})
+		return load, func() {
+			productSelect.Options = productOptions
+			productSelect.Refresh()
+			groupSelect.Options = groupOptions
+			groupSelect.Refresh()
+			showList()
+		}
+	})
+
+	var selectedPrescriptionID uint
+	list := newSelectionListWithData(listData,
+		func() fyne.CanvasObject {
+			return widget.NewLabel("template")
+		},
+		func(di binding.DataItem, co fyne.CanvasObject) {
+			co.(*widget.Label).Bind(di.(binding.String))
+		},
+	)
+	list.OnSelected = func(id widget.ListItemID) {
+		if id < len(prescriptionsList) {
+			selectedPrescriptionID = prescriptionsList[id].ID
+		}
+	}
+	sortSelect.OnChanged = func(string) {
+		updatePrescriptionList(listData, sortOrder())
+	}
+
+	editBtn := newButton(T("prescription.edit"), func() {
+		var pres Prescription
+		if selectedPrescriptionID == 0 || db.First(&pres, selectedPrescriptionID).Error != nil {
+			dialog.ShowError(errors.New(T("prescription.select_to_edit")), w)
+			return
+		}
+
+		productOptions, productMap = loadProductOptions()
+		groupOptions, groupMap = loadGroupOptions()
+
+		groupSelectEdit := widget.NewSelect(groupOptions, func(s string) {})
+		for opt, id := range groupMap {
+			if id == pres.GroupID {
+				groupSelectEdit.SetSelected(opt)
+				break
+			}
+		}
+		productSelectEdit := widget.NewSelect(productOptions, func(s string) {})
+		for opt, id := range productMap {
+			if id == pres.ProductID {
+				productSelectEdit.SetSelected(opt)
+				break
+			}
+		}
+		reqQtyEdit := newEntry()
+		reqQtyEdit.SetText(fmt.Sprintf("%.2f", pres.RequiredQuantity))
+		reqUnitEdit := newEntry()
+		reqUnitEdit.SetText(pres.RequiredUnit)
+		limitLength(reqUnitEdit, T("prescription.required_unit_field"), maxUnitLength)
+		seasonStartEdit := NewDatePicker()
+		seasonEndEdit := NewDatePicker()
+		setSeason(seasonStartEdit, seasonEndEdit, pres)
+
+		items := []*widget.FormItem{
+			widget.NewFormItem(T("prescription.group"), groupSelectEdit),
+			widget.NewFormItem(T("compare.product"), productSelectEdit),
+			widget.NewFormItem(T("prescription.required_quantity"), reqQtyEdit),
+			widget.NewFormItem(T("prescription.required_unit"), reqUnitEdit),
+			widget.NewFormItem(T("prescription.season_start"), seasonStartEdit),
+			widget.NewFormItem(T("prescription.season_end"), seasonEndEdit),
+		}
+		dlg := dialog.NewForm(T("prescription.edit_title"), T("common.save"), T("common.cancel"), items, func(ok bool) {
+			if !ok {
+				return
+			}
+			groupID, ok := groupMap[groupSelectEdit.Selected]
+			if !ok {
+				dialog.ShowError(errors.New(T("prescription.select_group")), w)
+				return
+			}
+			selectedProduct := productSelectEdit.Selected
+			if selectedProduct == "" {
+				dialog.ShowError(errors.New(T("common.select_product")), w)
+				return
+			}
+			productID, ok := productMap[selectedProduct]
+			if !ok {
+				dialog.ShowError(errors.New(T("quote.invalid_product")), w)
+				return
+			}
+			reqQty, err := strconv.ParseFloat(reqQtyEdit.Text, 64)
+			if err != nil {
+				dialog.ShowError(errors.New(T("prescription.invalid_quantity")), w)
+				return
+			}
+			if reqQty <= 0 {
+				dialog.ShowError(errors.New(T("prescription.quantity_positive")), w)
+				return
+			}
+			if reqUnitEdit.Text == "" {
+				dialog.ShowError(errors.New(T("prescription.unit_required")), w)
+				return
+			}
+			if err := checkLengths(reqUnitEdit); err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			var product Product
+			if err := db.First(&product, productID).Error; err != nil {
+				dialog.ShowError(errors.New(T("common.product_not_found")), w)
+				return
+			}
+			if !sameUnit(reqUnitEdit.Text, product.StandardUnit) {
+				if _, err := convertForProduct(reqQty, reqUnitEdit.Text, product); err != nil {
+					dialog.ShowError(errors.New(T("prescription.incompatible_unit", reqUnitEdit.Text, product.StandardUnit, err)), w)
+					return
+				}
+			}
+			seasonStart, seasonEnd, err := readSeason(seasonStartEdit, seasonEndEdit)
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			original := pres
+			pres.GroupID = groupID
+			pres.ProductID = productID
+			pres.RequiredQuantity = reqQty
+			pres.RequiredUnit = reqUnitEdit.Text
+			pres.SeasonStart = seasonStart
+			pres.SeasonEnd = seasonEnd
+			if !prescriptionChanged(original, pres) {
+				showNoChanges(w)
+				return
+			}
+			warning := ""
+			if pres.ProductID != original.ProductID {
+				warning = T("prescription.changed_product")
+			}
+			confirmSave(w, warning, func() {
+				if err := db.Save(&pres).Error; err != nil {
+					showDBError(err, w)
+					return
+				}
+				recordAudit(auditUpdate, "Receituário", pres.ID, "")
+				dialog.ShowInformation(T("common.success"), T("prescription.updated"), w)
+				updatePrescriptionList(listData, sortOrder())
+				productOptions, productMap = loadProductOptions()
+				productSelect.Options = productOptions
+				productSelect.Refresh()
+			})
+		}, w)
+		dlg.Show()
+	})
+
+	deleteBtn := newButton(T("prescription.delete"), func() {
+		var pres Prescription
+		if selectedPrescriptionID == 0 || db.First(&pres, selectedPrescriptionID).Error != nil {
+			dialog.ShowError(errors.New(T("prescription.select_to_delete")), w)
+			return
+		}
+		dialog.ShowConfirm(T("common.confirmation"), T("prescription.confirm_delete"), func(confirm bool) {
+			if confirm {
+				if err := db.Delete(&pres).Error; err != nil {
+					showDBError(err, w)
+					return
+				}
+				recordAudit(auditDelete, "Receituário", pres.ID, "")
+				dialog.ShowInformation(T("common.success"), T("prescription.deleted"), w)
+				updatePrescriptionList(listData, sortOrder())
+				productOptions, productMap = loadProductOptions()
+				productSelect.Options = productOptions
+				productSelect.Refresh()
+			}
+		}, w)
+	})
+
+	submitOnEnter(addBtn.OnTapped, reqQtyEntry, reqUnitEntry)
+	registerTabActions("tab.prescriptions", tabActions{
+		submit:         addBtn.OnTapped,
+		newRecord:      func() { w.Canvas().Focus(productSelect) },
+		deleteSelected: deleteBtn.OnTapped,
+	})
+
+	writeActions(newGroupBtn, addBtn, editBtn, deleteBtn)
+	return container.NewVBox(form, addBtn, refreshBtn, editBtn, deleteBtn, widget.NewLabel(T("prescription.list")), container.NewHBox(widget.NewLabel(T("quote.sort")), sortSelect), list)
+}
+
+func updatePrescriptionList(data binding.StringList, order string) {
+	var lines []string
+	prescriptionsList, lines = loadPrescriptionList(order)
+	data.Set(lines)
+}
+
+func loadPrescriptionList(order string) ([]Prescription, []string) {
+	var pres []Prescription
+	db.Preload("Product").Preload("Group").
+		Joins("LEFT JOIN products ON products.id = prescriptions.product_id").
+		Order(prescriptionOrderClause(order)).
+		Find(&pres)
+	var strs []string
+	for _, p := range pres {
+		line := fmt.Sprintf("%d: [%s] %s - %.2f %s", p.ID, p.Group.Name, p.Product.Name, p.RequiredQuantity, p.RequiredUnit)
+		if season := formatSeason(p); season != "" {
+			line += " (" + season + ")"
+		}
+		strs = append(strs, line)
+	}
+	return pres, strs
 }
 
 func reportTab(w fyne.Window) fyne.CanvasObject {
-	dateEntry := widget.NewEntry()
-	dateEntry.SetPlaceHolder("YYYY-MM-DD")
+	groupSelect := widget.NewSelect(append([]string{T(allGroupsOption)}, groupOptions...), func(s string) {})
+	groupSelect.SetSelected(T(allGroupsOption))
+	refreshGroupsBtn := newButton(T("common.refresh"), func() {
+		groupOptions, groupMap = loadGroupOptions()
+		groupSelect.Options = append([]string{T(allGroupsOption)}, groupOptions...)
+		groupSelect.SetSelected(T(allGroupsOption))
+	})
+	selectedGroupID := func() uint {
+		return groupMap[groupSelect.Selected]
+	}
+	registerRefresh(refreshWidgets(func() {
+		groupSelect.Options = append([]string{T(allGroupsOption)}, groupOptions...)
+		if _, ok := groupMap[groupSelect.Selected]; !ok && groupSelect.Selected != T(allGroupsOption) {
+			groupSelect.SetSelected(T(allGroupsOption))
+		}
+		groupSelect.Refresh()
+	}))
+	categoryFilter := newCategoryFilter(nil)
+	startPicker := NewDatePicker()
+	endPicker := NewDatePicker()
+	restoreReportDates(startPicker, endPicker)
+	readReportRange := func() (time.Time, time.Time, error) {
+		start, end, err := readDateRange(startPicker, endPicker)
+		if err == nil {
+			saveReportDates(start, end)
+		}
+		return start, end, err
+	}
+	toleranceEntry := preferredToleranceEntry()
+	deliveryEntry := deliveryLimitEntry()
 	form := widget.NewForm(
-		widget.NewFormItem("Data", dateEntry),
+		widget.NewFormItem(T("prescription.group"), container.NewBorder(nil, nil, nil, refreshGroupsBtn, groupSelect)),
+		widget.NewFormItem(T("product.category"), categoryFilter),
+		widget.NewFormItem(T("common.start_date"), startPicker),
+		widget.NewFormItem(T("common.end_date"), endPicker),
+		widget.NewFormItem(T("report.tolerance"), toleranceEntry),
+		widget.NewFormItem(T("report.delivery_limit"), deliveryEntry),
 	)
-	reportLabel := widget.NewLabel("")
-	fullReportLabel := widget.NewLabel("")
+	reportView := newReportView()
+	fullReportView := newReportView()
+
+	generating := false
+	var genBtn *widget.Button
+	genBtn = newButton(T("report.generate"), func() {
+		if generating {
+			return
+		}
+		if !connectionAvailable(w) {
+			return
+		}
+		start, end, err := readReportRange()
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		groupID, category, opts := selectedGroupID(), selectedCategory(categoryFilter), currentReportOptions()
+		generating = true
+		genBtn.Disable()
+		reportView.SetText(T("report.generating"))
+		go func() {
+			report := generateReportByDate(groupID, category, start, end, opts)
+			fyne.Do(func() {
+				reportView.SetText(report)
+				generating = false
+				genBtn.Enable()
+			})
+		}()
+	})
+
+	showAllBtn := newButton(T("report.full"), func() {
+		if !connectionAvailable(w) {
+			return
+		}
+		start, end, err := readReportRange()
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		groupID, category, opts := selectedGroupID(), selectedCategory(categoryFilter), currentReportOptions()
+		var fullReport string
+		runWithProgress(w, T("report.full_generating"), func() {
+			fullReport = generateFullReportByDate(groupID, category, start, end, opts)
+		}, func() {
+			fullReportView.SetText(fullReport)
+		})
+	})
+
+	scoreView := newReportView()
+	scoreBtn := newButton(T("report.score"), func() {
+		if !connectionAvailable(w) {
+			return
+		}
+		start, end, err := readReportRange()
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		groupID, category, opts := selectedGroupID(), selectedCategory(categoryFilter), currentReportOptions()
+		showScoreWeightsDialog(w, func(weights scoreWeights) {
+			var report string
+			runWithProgress(w, T("report.score_generating"), func() {
+				report = generateScoreReport(groupID, category, start, end, weights, opts)
+			}, func() {
+				scoreView.SetText(report)
+			})
+		})
+	})
+
+	bestStoreLabel := widget.NewLabel("")
+	bestStoreBtn := newButton(T("report.best_store"), func() {
+		start, end, err := readReportRange()
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		groupID, category, opts := selectedGroupID(), selectedCategory(categoryFilter), currentReportOptions()
+		var report string
+		runWithProgress(w, T("report.best_store_generating"), func() {
+			report = generateBestStoreOverall(groupID, category, start, end, opts)
+		}, func() {
+			bestStoreLabel.SetText(report)
+		})
+	})
+
+	exportPDFBtn := newButton(T("report.export_pdf"), func() {
+		start, end, err := readReportRange()
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		groupID, category, opts := selectedGroupID(), selectedCategory(categoryFilter), currentReportOptions()
+		var fullReport string
+		saveDlg := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			if writer == nil {
+				return
+			}
+			defer writer.Close()
+			title := T("report.winners_losers_title", formatPeriod(start, end))
+			if err := writeReportPDF(writer, title, fullReport); err != nil {
+				dialog.ShowError(errors.New(T("report.pdf_error", err)), w)
+				return
+			}
+			dialog.ShowInformation(T("common.success"), T("report.pdf_exported"), w)
+		}, w)
+		saveDlg.SetFileName(fmt.Sprintf("relatorio_%s.pdf", start.Format("2006-01-02")))
+		runWithProgress(w, T("report.pdf_generating"), func() {
+			fullReport = generateFullReportByDate(groupID, category, start, end, opts)
+		}, saveDlg.Show)
+	})
 
-	genBtn := widget.NewButton("Gerar Relatório por Data", func() {
-		dateStr := dateEntry.Text
-		if dateStr == "" {
-			dialog.ShowError(fmt.Errorf("Data é obrigatória"), w)
-			return
-		}
-		t, err := time.Parse("2006-01-02", dateStr)
+	exportCSVBtn := newButton(T("common.export_csv"), func() {
+		start, end, err := readReportRange()
 		if err != nil {
-			dialog.ShowError(fmt.Errorf("Formato de data inválido (use YYYY-MM-DD)"), w)
+			dialog.ShowError(err, w)
 			return
 		}
-		report := generateReportByDate(t)
-		reportLabel.SetText(report)
+		groupID, category, opts := selectedGroupID(), selectedCategory(categoryFilter), currentReportOptions()
+		var rows [][]string
+		var loadErr error
+		runWithProgress(w, T("report.csv_generating"), func() {
+			rows, loadErr = reportCSVRows(groupID, category, start, end, opts)
+		}, func() {
+			if loadErr != nil {
+				showDBError(loadErr, w)
+				return
+			}
+			saveCSV(w, fmt.Sprintf("relatorio_%s.csv", start.Format("2006-01-02")), rows)
+		})
 	})
 
-	showAllBtn := widget.NewButton("Mostrar Vencedores e Perdedores", func() {
-		dateStr := dateEntry.Text
-		if dateStr == "" {
-			dialog.ShowError(fmt.Errorf("Data é obrigatória"), w)
+	emailBtn := newButton(T("report.email"), func() {
+		start, end, err := readReportRange()
+		if err != nil {
+			dialog.ShowError(err, w)
 			return
 		}
-		t, err := time.Parse("2006-01-02", dateStr)
-		if err != nil {
-			dialog.ShowError(fmt.Errorf("Formato de data inválido (use YYYY-MM-DD)"), w)
+		if !smtpConfigured() {
+			dialog.ShowError(errors.New(T("mail.not_configured")), w)
+			return
+		}
+		groupID, category, opts := selectedGroupID(), selectedCategory(categoryFilter), currentReportOptions()
+		toEntry := newEntry()
+		toEntry.SetPlaceHolder(T("report.email_placeholder"))
+		formatSelect := widget.NewRadioGroup([]string{T("report.format_text"), "PDF"}, nil)
+		formatSelect.Horizontal = true
+		formatSelect.SetSelected("PDF")
+		dialog.ShowForm(T("report.email_title"), T("common.send"), T("common.cancel"), []*widget.FormItem{
+			widget.NewFormItem(T("report.recipient"), toEntry),
+			widget.NewFormItem(T("report.format"), formatSelect),
+		}, func(ok bool) {
+			if !ok {
+				return
+			}
+			to, err := validateEmail(toEntry.Text)
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			asPDF := formatSelect.Selected == "PDF"
+			title := T("report.winners_losers_title", formatPeriod(start, end))
+			var sendErr error
+			runWithProgress(w, T("report.email_sending"), func() {
+				report := generateFullReportByDate(groupID, category, start, end, opts)
+				if !asPDF {
+					sendErr = sendEmail(to, title, report)
+					return
+				}
+				var buf bytes.Buffer
+				if err := writeReportPDF(&buf, title, report); err != nil {
+					sendErr = errors.New(T("report.pdf_error", err))
+					return
+				}
+				body := T("report.email_attachment_body", strings.ToLower(title[:1])+title[1:], companyName())
+				sendErr = sendEmailWithAttachment(to, title, body, &emailAttachment{
+					fileName:    fmt.Sprintf("relatorio_%s.pdf", start.Format("2006-01-02")),
+					contentType: "application/pdf",
+					data:        buf.Bytes(),
+				})
+			}, func() {
+				if sendErr != nil {
+					dialog.ShowError(sendErr, w)
+					return
+				}
+				dialog.ShowInformation(T("common.success"), T("report.email_sent", to), w)
+			})
+		}, w)
+	})
+
+	missingLabel := widget.NewLabel("")
+	missingBtn := newButton(T("report.missing"), func() {
+		date, ok := startPicker.Date()
+		if !ok {
+			dialog.ShowError(errors.New(T("report.start_required")), w)
 			return
 		}
-		fullReport := generateFullReportByDate(t)
-		fullReportLabel.SetText(fullReport)
+		var report string
+		runWithProgress(w, T("report.missing_generating"), func() {
+			report = generateMissingQuotesReport(date)
+		}, func() {
+			missingLabel.SetText(report)
+		})
 	})
 
-	return container.NewVBox(form, genBtn, reportLabel, showAllBtn, fullReportLabel)
+	copyReportBtn := copyReportButton(w, func() string { return reportView.Text })
+	copyFullReportBtn := copyReportButton(w, func() string { return fullReportView.Text })
+	copyScoreBtn := copyReportButton(w, func() string { return scoreView.Text })
+
+	return container.NewVBox(form, container.NewHBox(genBtn, copyReportBtn), scrollableReport(reportView), container.NewHBox(showAllBtn, copyFullReportBtn), scrollableReport(fullReportView), container.NewHBox(scoreBtn, copyScoreBtn), scrollableReport(scoreView), bestStoreBtn, bestStoreLabel, missingBtn, missingLabel, exportPDFBtn, exportCSVBtn, emailBtn)
 }
 
-func generateReportByDate(date time.Time) string {
+func loadReportPrescriptions(groupID uint, category string, start, end time.Time) []Prescription {
+	prepareExchangeRates()
 	var prescriptions []Prescription
-	db.Preload("Product").Find(&prescriptions)
+	query := db.Preload("Product").Preload("Group").
+		Joins("LEFT JOIN products ON products.id = prescriptions.product_id").
+		Scopes(inSeason(start, end))
+	if groupID != 0 {
+		query = query.Where("prescriptions.group_id = ?", groupID)
+	}
+	if category != "" {
+		query = query.Where("products.category = ?", category)
+	}
+	query.Order("products.category, prescriptions.id").Find(&prescriptions)
+	return prescriptions
+}
+
+func writeCategoryHeader(sb *strings.Builder, current *string, pres Prescription) {
+	if pres.Product.ID == 0 || pres.Product.Category == *current {
+		return
+	}
+	*current = pres.Product.Category
+	sb.WriteString(T("report.category_header", categoryLabel(*current)))
+}
+
+func readDateRange(startPicker, endPicker *DatePicker) (time.Time, time.Time, error) {
+	start, ok := startPicker.Date()
+	if !ok {
+		return time.Time{}, time.Time{}, errors.New(T("report.start_required"))
+	}
+	end, ok := endPicker.Date()
+	if !ok {
+		end = start
+	}
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, errors.New(T("report.end_before_start"))
+	}
+	return start, end, nil
+}
+
+func formatPeriod(start, end time.Time) string {
+	if start.Equal(end) {
+		return start.Format("2006-01-02")
+	}
+	return T("report.period_range", start.Format("2006-01-02"), end.Format("2006-01-02"))
+}
+
+func generateReportByDate(groupID uint, category string, start, end time.Time, opts reportOptions) string {
+	prescriptions := loadReportPrescriptions(groupID, category, start, end)
 
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("Relatório de Cotações Vencedoras para %s:\n\n", date.Format("2006-01-02")))
+	sb.WriteString(T("report.winners_title", formatPeriod(start, end)))
 
+	var currentCategory string
 	for _, pres := range prescriptions {
+		writeCategoryHeader(&sb, &currentCategory, pres)
 		if pres.Product.ID == 0 {
-			sb.WriteString(fmt.Sprintf("Produto com ID %d não encontrado.\n", pres.ProductID))
+			sb.WriteString(T("report.product_not_found", pres.ProductID))
 			continue
 		}
 
-		if pres.RequiredUnit != pres.Product.StandardUnit {
-			sb.WriteString(fmt.Sprintf("Unidade requerida '%s' não combina com padrão '%s' para '%s'.\n", pres.RequiredUnit, pres.Product.StandardUnit, pres.Product.Name))
+		requiredQty, err := requiredStandardQuantity(pres)
+		if err != nil {
+			sb.WriteString(T("report.unit_mismatch", pres.RequiredUnit, pres.Product.StandardUnit, pres.Product.Name))
 			continue
 		}
 
-		var quotes []Quote
-		db.Preload("Store").Where("product_id = ? AND date = ?", pres.ProductID, date).Find(&quotes)
+		quotes, err := quoteRepo.FindByDate(pres.ProductID, start, end)
+		if err != nil {
+			sb.WriteString(T("report.quotes_load_error", pres.Product.Name, err))
+			continue
+		}
 
 		if len(quotes) == 0 {
-			sb.WriteString(fmt.Sprintf("Nenhuma cotação para '%s' na data %s.\n", pres.Product.Name, date.Format("2006-01-02")))
+			sb.WriteString(T("report.no_quotes", pres.Product.Name, formatPeriod(start, end)))
 			continue
 		}
 
-		minCost := float64(999999999)
-		var bestQuote Quote
-		var bestStore Store
+		quotes, expired := splitExpiredQuotes(quotes, end)
+		for _, q := range expired {
+			sb.WriteString(T("report.expired_ignored", q.ID, q.Store.Name, q.ValidUntil.Format("2006-01-02")))
+		}
+		quotes, late := filterByDelivery(quotes, opts.deliveryLimit)
+		for _, q := range late {
+			sb.WriteString(describeLateQuote(q, *opts.deliveryLimit))
+		}
+		if len(quotes) == 0 && len(late) > 0 {
+			sb.WriteString(T("report.none_within_delivery", pres.Product.Name, *opts.deliveryLimit))
+			continue
+		}
 
-		for _, quote := range quotes {
-			pricePerStandard := quote.Price / (quote.PackagingSize * quote.ConversionFactor)
-			totalCost := pricePerStandard * pres.RequiredQuantity
+		costs, skipped := rankQuotes(quotes, requiredQty, opts.tolerance)
+		for _, quote := range skipped {
+			_, err := quoteTotalCost(quote, requiredQty)
+			sb.WriteString(T("report.quote_ignored", quote.ID, err))
+		}
 
-			if totalCost < minCost {
-				minCost = totalCost
-				bestQuote = quote
-				bestStore = quote.Store
+		if len(costs) > 0 {
+			bestQuote, bestStore := costs[0].quote, costs[0].quote.Store
+			sb.WriteString(T("report.for_product", pres.Product.Name, formatRequiredQuantity(pres, requiredQty)))
+			sb.WriteString(T("report.winner_line", bestStore.Name, bestStore.Endereco, formatCost(costs[0])))
+			sb.WriteString(describeStoreContact(bestStore, "  "))
+			sb.WriteString(describeTie(tiedWithWinner(costs)))
+			sb.WriteString(describePreference(costs[0]))
+			sb.WriteString(T("report.details", formatQuotePrice(bestQuote), bestQuote.PackagingSize, bestQuote.PackagingUnit, bestQuote.ConversionFactor, bestQuote.Date.Format("2006-01-02")))
+			sb.WriteString(T("report.delivery", formatDeliveryDays(bestQuote)))
+			if bestQuote.Notes != "" {
+				sb.WriteString(T("report.notes", bestQuote.Notes))
+			}
+			if !meetsMinOrder(bestQuote, requiredQty) {
+				sb.WriteString(T("report.min_order_not_met_required", bestQuote.MinOrderQuantity, pres.Product.StandardUnit))
+				if next, found := nextViableQuote(costs, requiredQty); found {
+					sb.WriteString(T("report.next_viable_address", next.quote.Store.Name, next.quote.Store.Endereco, formatCost(next)))
+				} else {
+					sb.WriteString(T("report.no_other_viable"))
+				}
 			}
+			sb.WriteString("\n")
 		}
+	}
 
-		if bestQuote.ID != 0 {
-			sb.WriteString(fmt.Sprintf("Para '%s' (%.2f %s):\n", pres.Product.Name, pres.RequiredQuantity, pres.RequiredUnit))
-			sb.WriteString(fmt.Sprintf("  Vencedor: Loja '%s' (%s) - Custo Total: R$ %.2f\n", bestStore.Name, bestStore.Endereco, minCost))
-			sb.WriteString(fmt.Sprintf("  Detalhes: Preço R$ %.2f por %.2f %s (Conv: %.2f) em %s\n\n", bestQuote.Price, bestQuote.PackagingSize, bestQuote.PackagingUnit, bestQuote.ConversionFactor, bestQuote.Date.Format("2006-01-02")))
-		}
+	return sb.String()
+}
+
+func describeSavings(costs []quoteCost) string {
+	if len(costs) < 2 {
+		return T("report.savings_single")
+	}
+	winner := costs[0].cost
+	second := costs[1].cost
+	var sum float64
+	for _, qc := range costs {
+		sum += qc.cost
 	}
+	average := sum / float64(len(costs))
 
+	var sb strings.Builder
+	sb.WriteString(T("report.savings_second", costs[1].quote.Store.Name, second-winner, formatPercent(second-winner, second)))
+	sb.WriteString(T("report.savings_average", len(costs), average, average-winner, formatPercent(average-winner, average)))
 	return sb.String()
 }
 
-func generateFullReportByDate(date time.Time) string {
-	var prescriptions []Prescription
-	db.Preload("Product").Find(&prescriptions)
+func formatPercent(part, total float64) string {
+	if total == 0 {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.1f%%", part/total*100)
+}
+
+func parseMinOrderQuantity(text string) (float64, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0, nil
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, errors.New(T("quote.invalid_min_order"))
+	}
+	if value < 0 {
+		return 0, errors.New(T("quote.negative_min_order"))
+	}
+	return value, nil
+}
+
+func meetsMinOrder(q Quote, requiredQty float64) bool {
+	return q.MinOrderQuantity <= 0 || requiredQty >= q.MinOrderQuantity
+}
+
+func nextViableQuote(costs []quoteCost, requiredQty float64) (quoteCost, bool) {
+	for _, qc := range costs {
+		if meetsMinOrder(qc.quote, requiredQty) {
+			return qc, true
+		}
+	}
+	return quoteCost{}, false
+}
+
+func quoteExpired(q Quote, ref time.Time) bool {
+	return q.ValidUntil != nil && q.ValidUntil.Before(ref)
+}
+
+func splitExpiredQuotes(quotes []Quote, ref time.Time) ([]Quote, []Quote) {
+	var valid, expired []Quote
+	for _, q := range quotes {
+		if quoteExpired(q, ref) {
+			expired = append(expired, q)
+		} else {
+			valid = append(valid, q)
+		}
+	}
+	return valid, expired
+}
+
+type quoteCost struct {
+	quote         Quote
+	productCost   float64
+	shipping      float64
+	cost          float64
+	tier          *PriceTier
+	preferredOver *quoteCost
+	tolerance     float64
+}
+
+func quoteTotalCost(quote Quote, requiredQty float64) (quoteCost, error) {
+	if quote.PackagingSize*quote.ConversionFactor == 0 {
+		return quoteCost{}, fmt.Errorf(T("report.zero_divisor"))
+	}
+	rate, err := exchangeRate(quote.Currency)
+	if err != nil {
+		return quoteCost{}, err
+	}
+	var applied *PriceTier
+	if tier, ok := applicableTier(quote, requiredQty); ok {
+		applied = &tier
+	}
+	productCost := computeProductCost(quote, requiredQty) * rate
+	shipping := computeShipping(quote, requiredQty) * rate
+	return quoteCost{quote: quote, productCost: productCost, shipping: shipping, cost: productCost + shipping, tier: applied}, nil
+}
+
+func formatCost(qc quoteCost) string {
+	if qc.shipping == 0 {
+		return fmt.Sprintf("R$ %.2f", qc.cost)
+	}
+	return T("report.cost_with_shipping", qc.cost, qc.productCost, qc.shipping)
+}
+
+func rankQuotes(quotes []Quote, requiredQty, tolerance float64) ([]quoteCost, []Quote) {
+	var costs []quoteCost
+	var skipped []Quote
+	for _, quote := range quotes {
+		qc, err := quoteTotalCost(quote, requiredQty)
+		if err != nil {
+			skipped = append(skipped, quote)
+			continue
+		}
+		costs = append(costs, qc)
+	}
+
+	var storeIDs []uint
+	for _, qc := range costs {
+		storeIDs = append(storeIDs, qc.quote.StoreID)
+	}
+	sortByCost(costs, storesWithContacts(storeIDs))
+	return preferPreferredStore(costs, tolerance), skipped
+}
+
+func costCents(cost float64) int64 {
+	return int64(math.Round(cost * 100))
+}
+
+// Critério de desempate quando o custo total é igual ao centavo:
+// 1) loja com contato cadastrado, por ser contatável para fechar o pedido;
+// 2) cotação mais recente;
+// 3) ordem em que as cotações foram carregadas.
+const tieBreakCriteria = "report.tie_criteria"
+
+func winsTie(a, b Quote, withContact map[uint]bool) bool {
+	aPhone, bPhone := withContact[a.StoreID], withContact[b.StoreID]
+	if aPhone != bPhone {
+		return aPhone
+	}
+	return a.Date.After(b.Date)
+}
+
+func tiedWithWinner(costs []quoteCost) []quoteCost {
+	if len(costs) == 0 {
+		return nil
+	}
+	tied := costs[:1]
+	for _, qc := range costs[1:] {
+		if costCents(qc.cost) != costCents(costs[0].cost) {
+			break
+		}
+		tied = append(tied, qc)
+	}
+	return tied
+}
+
+func describeTie(tied []quoteCost) string {
+	if len(tied) < 2 {
+		return ""
+	}
+	var names []string
+	for _, qc := range tied {
+		names = append(names, T("report.tie_store", qc.quote.Store.Name))
+	}
+	last := len(names) - 1
+	return T("report.tie", strings.Join(names[:last], ", "), names[last], T(tieBreakCriteria))
+}
+
+func generateFullReportByDate(groupID uint, category string, start, end time.Time, opts reportOptions) string {
+	prescriptions := loadReportPrescriptions(groupID, category, start, end)
 
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("Relatório Completo de Cotações (Vencedores e Perdedores) para %s:\n\n", date.Format("2006-01-02")))
+	sb.WriteString(T("report.full_title", formatPeriod(start, end)))
 
+	var totals purchaseTotals
+	var scoreboard storeScoreboard
+	var currentCategory string
 	for _, pres := range prescriptions {
+		writeCategoryHeader(&sb, &currentCategory, pres)
 		if pres.Product.ID == 0 {
-			sb.WriteString(fmt.Sprintf("Produto com ID %d não encontrado.\n", pres.ProductID))
+			sb.WriteString(T("report.product_not_found", pres.ProductID))
+			totals.exclude(fmt.Sprintf("ID %d", pres.ProductID))
 			continue
 		}
 
-		if pres.RequiredUnit != pres.Product.StandardUnit {
-			sb.WriteString(fmt.Sprintf("Unidade requerida '%s' não combina com padrão '%s' para '%s'.\n", pres.RequiredUnit, pres.Product.StandardUnit, pres.Product.Name))
+		requiredQty, err := requiredStandardQuantity(pres)
+		if err != nil {
+			sb.WriteString(T("report.unit_mismatch", pres.RequiredUnit, pres.Product.StandardUnit, pres.Product.Name))
+			totals.exclude(pres.Product.Name)
 			continue
 		}
 
-		var quotes []Quote
-		db.Preload("Store").Where("product_id = ? AND date = ?", pres.ProductID, date).Find(&quotes)
+		quotes, err := quoteRepo.FindByDate(pres.ProductID, start, end)
+		if err != nil {
+			sb.WriteString(T("report.quotes_load_error", pres.Product.Name, err))
+			totals.exclude(pres.Product.Name)
+			continue
+		}
 
 		if len(quotes) == 0 {
-			sb.WriteString(fmt.Sprintf("Nenhuma cotação para '%s' na data %s.\n", pres.Product.Name, date.Format("2006-01-02")))
+			sb.WriteString(T("report.no_quotes", pres.Product.Name, formatPeriod(start, end)))
+			totals.exclude(pres.Product.Name)
 			continue
 		}
 
-		type quoteCost struct {
-			quote Quote
-			cost  float64
+		quotes, expired := splitExpiredQuotes(quotes, end)
+		quotes, late := filterByDelivery(quotes, opts.deliveryLimit)
+		for _, q := range late {
+			sb.WriteString(describeLateQuote(q, *opts.deliveryLimit))
 		}
-		var costs []quoteCost
-		for _, quote := range quotes {
-			pricePerStandard := quote.Price / (quote.PackagingSize * quote.ConversionFactor)
-			totalCost := pricePerStandard * pres.RequiredQuantity
-			costs = append(costs, quoteCost{quote: quote, cost: totalCost})
+		costs, skipped := rankQuotes(quotes, requiredQty, opts.tolerance)
+		for _, q := range skipped {
+			_, err := quoteTotalCost(q, requiredQty)
+			sb.WriteString(T("report.quote_ignored", q.ID, err))
 		}
-
-		for i := range costs {
-			for j := i + 1; j < len(costs); j++ {
-				if costs[i].cost > costs[j].cost {
-					costs[i], costs[j] = costs[j], costs[i]
-				}
-			}
+		if len(costs) > 0 {
+			totals.add(costs)
+			scoreboard.add(costs)
+		} else {
+			totals.exclude(pres.Product.Name)
+		}
+		if len(costs) == 0 && len(expired) == 0 {
+			continue
 		}
 
-		sb.WriteString(fmt.Sprintf("Para '%s' (%.2f %s):\n", pres.Product.Name, pres.RequiredQuantity, pres.RequiredUnit))
+		sb.WriteString(T("report.for_product", pres.Product.Name, formatRequiredQuantity(pres, requiredQty)))
 		for idx, qc := range costs {
-			status := "Perdedor"
+			status := T("report.loser")
+			if idx == 0 {
+				status = T("report.winner")
+			}
+			sb.WriteString(T("report.ranked_line", status, qc.quote.Store.Name, qc.quote.Store.Endereco, formatCost(qc)))
 			if idx == 0 {
-				status = "Vencedor"
+				sb.WriteString(describeStoreContact(qc.quote.Store, "    "))
+			}
+			sb.WriteString(T("report.details_nested", formatQuotePrice(qc.quote), qc.quote.PackagingSize, qc.quote.PackagingUnit, qc.quote.ConversionFactor, qc.quote.Date.Format("2006-01-02")))
+			sb.WriteString(T("report.delivery_nested", formatDeliveryDays(qc.quote)))
+			if qc.quote.Notes != "" {
+				sb.WriteString(T("report.notes_nested", qc.quote.Notes))
+			}
+			if qc.tier != nil {
+				sb.WriteString(T("report.volume_discount", formatTier(qc.quote, *qc.tier)))
+			}
+			if !meetsMinOrder(qc.quote, requiredQty) {
+				sb.WriteString(T("report.min_order_not_met", qc.quote.MinOrderQuantity, pres.Product.StandardUnit))
+			}
+		}
+		if len(costs) > 0 {
+			sb.WriteString(describeTie(tiedWithWinner(costs)))
+			sb.WriteString(describePreference(costs[0]))
+			sb.WriteString(describeSavings(costs))
+		}
+		if len(costs) > 0 && !meetsMinOrder(costs[0].quote, requiredQty) {
+			if next, found := nextViableQuote(costs, requiredQty); found {
+				sb.WriteString(T("report.next_viable", next.quote.Store.Name, formatCost(next)))
+			} else {
+				sb.WriteString(T("report.no_viable"))
+			}
+		}
+		for _, q := range expired {
+			sb.WriteString(T("report.expired_line", q.Store.Name, q.Store.Endereco, q.ValidUntil.Format("2006-01-02")))
+			sb.WriteString(T("report.details_nested", formatQuotePrice(q), q.PackagingSize, q.PackagingUnit, q.ConversionFactor, q.Date.Format("2006-01-02")))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(totals.summary())
+	sb.WriteString("\n")
+	sb.WriteString(scoreboard.summary())
+	return sb.String()
+}
+
+type purchaseTotals struct {
+	optimized float64
+	worst     float64
+	priced    int
+	excluded  []string
+}
+
+func (t *purchaseTotals) add(costs []quoteCost) {
+	t.optimized += costs[0].cost
+	t.worst += costs[len(costs)-1].cost
+	t.priced++
+}
+
+func (t *purchaseTotals) exclude(name string) {
+	t.excluded = append(t.excluded, name)
+}
+
+func (t purchaseTotals) summary() string {
+	var sb strings.Builder
+	sb.WriteString(T("report.summary_title"))
+	if t.priced == 0 {
+		sb.WriteString(T("report.summary_none"))
+	} else {
+		savings := t.worst - t.optimized
+		sb.WriteString(T("report.summary_optimized", t.priced, t.optimized))
+		sb.WriteString(T("report.summary_worst", t.worst))
+		sb.WriteString(T("report.summary_savings", savings, formatPercent(savings, t.worst)))
+	}
+	if len(t.excluded) > 0 {
+		sb.WriteString(T("report.summary_excluded", len(t.excluded), strings.Join(t.excluded, ", ")))
+	}
+	return sb.String()
+}
+
+type storeRecord struct {
+	name   string
+	wins   int
+	losses int
+}
+
+type storeScoreboard struct {
+	records  map[uint]*storeRecord
+	order    []uint
+	products int
+}
+
+func (b *storeScoreboard) add(costs []quoteCost) {
+	if b.records == nil {
+		b.records = make(map[uint]*storeRecord)
+	}
+	b.products++
+	seen := make(map[uint]bool)
+	for idx, qc := range costs {
+		id := qc.quote.StoreID
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		rec, ok := b.records[id]
+		if !ok {
+			rec = &storeRecord{name: qc.quote.Store.Name}
+			b.records[id] = rec
+			b.order = append(b.order, id)
+		}
+		if idx == 0 {
+			rec.wins++
+		} else {
+			rec.losses++
+		}
+	}
+}
+
+func (b storeScoreboard) summary() string {
+	var sb strings.Builder
+	sb.WriteString(T("report.scoreboard_title"))
+	if len(b.order) == 0 {
+		sb.WriteString(T("report.scoreboard_none"))
+		return sb.String()
+	}
+	var records []*storeRecord
+	for _, id := range b.order {
+		records = append(records, b.records[id])
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].wins != records[j].wins {
+			return records[i].wins > records[j].wins
+		}
+		return records[i].losses < records[j].losses
+	})
+	for _, rec := range records {
+		quoted := rec.wins + rec.losses
+		sb.WriteString(T("report.scoreboard_line",
+			rec.name, rec.wins, rec.losses, quoted, b.products, formatPercent(float64(rec.wins), float64(quoted))))
+	}
+	return sb.String()
+}
+
+func generateMissingQuotesReport(date time.Time) string {
+	prescriptions := loadReportPrescriptions(0, "", date, date)
+
+	var sb strings.Builder
+	sb.WriteString(T("report.missing_title", date.Format("2006-01-02")))
+
+	seen := make(map[uint]bool)
+	checked, missing := 0, 0
+	var currentCategory string
+	for _, pres := range prescriptions {
+		if pres.Product.ID == 0 || seen[pres.ProductID] {
+			continue
+		}
+		seen[pres.ProductID] = true
+		checked++
+
+		requiredQty, err := requiredStandardQuantity(pres)
+		if err != nil {
+			writeCategoryHeader(&sb, &currentCategory, pres)
+			sb.WriteString(T("report.missing_unit_mismatch", pres.Product.Name, pres.RequiredUnit, pres.Product.StandardUnit))
+			missing++
+			continue
+		}
+
+		quotes, err := quoteRepo.FindByDate(pres.ProductID, date, date)
+		if err != nil {
+			writeCategoryHeader(&sb, &currentCategory, pres)
+			sb.WriteString(T("report.quotes_load_error", pres.Product.Name, err))
+			missing++
+			continue
+		}
+		quotes, expired := splitExpiredQuotes(quotes, date)
+		usable, invalid := 0, 0
+		for _, q := range quotes {
+			if q.PackagingSize*q.ConversionFactor == 0 {
+				invalid++
+				continue
+			}
+			usable++
+		}
+		if usable > 0 {
+			continue
+		}
+
+		var suppliers int64
+		db.Model(&Quote{}).Where("product_id = ?", pres.ProductID).Distinct("store_id").Count(&suppliers)
+
+		writeCategoryHeader(&sb, &currentCategory, pres)
+		sb.WriteString(T("report.missing_product", pres.Product.Name, formatRequiredQuantity(pres, requiredQty)))
+		if suppliers == 0 {
+			sb.WriteString(T("report.missing_no_supplier"))
+		} else {
+			var last Quote
+			db.Where("product_id = ?", pres.ProductID).Order("date desc").First(&last)
+			sb.WriteString(T("report.missing_suppliers", suppliers, last.Date.Format("2006-01-02")))
+		}
+		if len(expired) > 0 {
+			sb.WriteString(T("report.missing_expired", len(expired)))
+		}
+		if invalid > 0 {
+			sb.WriteString(T("report.missing_invalid", invalid))
+		}
+		missing++
+	}
+
+	if checked == 0 {
+		sb.WriteString(T("report.missing_no_products"))
+	} else if missing == 0 {
+		sb.WriteString(T("report.missing_all_quoted", checked, date.Format("2006-01-02")))
+	} else {
+		sb.WriteString(T("report.missing_total", missing, checked))
+	}
+	return sb.String()
+}
+
+func generateBestStoreOverall(groupID uint, category string, start, end time.Time, opts reportOptions) string {
+	prescriptions := loadReportPrescriptions(groupID, category, start, end)
+
+	var sb strings.Builder
+	sb.WriteString(T("report.best_store_title", formatPeriod(start, end)))
+
+	type requiredItem struct {
+		pres Prescription
+		qty  float64
+	}
+	var items []requiredItem
+	for _, pres := range prescriptions {
+		if pres.Product.ID == 0 {
+			continue
+		}
+		qty, err := requiredStandardQuantity(pres)
+		if err != nil {
+			continue
+		}
+		items = append(items, requiredItem{pres: pres, qty: qty})
+	}
+	if len(items) == 0 {
+		sb.WriteString(T("report.best_store_no_prescriptions"))
+		return sb.String()
+	}
+
+	type storeTotal struct {
+		store   Store
+		covered int
+		total   float64
+		items   map[int]bool
+		missing []string
+	}
+	totals := make(map[uint]*storeTotal)
+	var order []uint
+
+	for idx, item := range items {
+		quotes, err := quoteRepo.FindByDate(item.pres.ProductID, start, end)
+		if err != nil {
+			sb.WriteString(T("report.quotes_load_error", item.pres.Product.Name, err))
+			continue
+		}
+		quotes, _ = splitExpiredQuotes(quotes, end)
+		quotes, _ = filterByDelivery(quotes, opts.deliveryLimit)
+		bestByStore := make(map[uint]float64)
+		for _, q := range quotes {
+			qc, err := quoteTotalCost(q, item.qty)
+			if err != nil {
+				continue
+			}
+			cost := qc.cost
+			if current, found := bestByStore[q.StoreID]; !found || cost < current {
+				bestByStore[q.StoreID] = cost
+			}
+			if _, found := totals[q.StoreID]; !found {
+				totals[q.StoreID] = &storeTotal{store: q.Store, items: make(map[int]bool)}
+				order = append(order, q.StoreID)
+			}
+		}
+		for storeID, cost := range bestByStore {
+			totals[storeID].covered++
+			totals[storeID].total += cost
+			totals[storeID].items[idx] = true
+		}
+	}
+
+	if len(order) == 0 {
+		sb.WriteString(T("report.best_store_no_quotes"))
+		return sb.String()
+	}
+
+	var ranking []*storeTotal
+	for _, storeID := range order {
+		st := totals[storeID]
+		for idx, item := range items {
+			if !st.items[idx] {
+				st.missing = append(st.missing, item.pres.Product.Name)
 			}
-			sb.WriteString(fmt.Sprintf("  %s: Loja '%s' (%s) - Custo Total: R$ %.2f\n", status, qc.quote.Store.Name, qc.quote.Store.Endereco, qc.cost))
-			sb.WriteString(fmt.Sprintf("    Detalhes: Preço R$ %.2f por %.2f %s (Conv: %.2f) em %s\n", qc.quote.Price, qc.quote.PackagingSize, qc.quote.PackagingUnit, qc.quote.ConversionFactor, qc.quote.Date.Format("2006-01-02")))
 		}
+		ranking = append(ranking, st)
+	}
+	sort.SliceStable(ranking, func(i, j int) bool {
+		if ranking[i].covered != ranking[j].covered {
+			return ranking[i].covered > ranking[j].covered
+		}
+		return ranking[i].total < ranking[j].total
+	})
+
+	best := ranking[0]
+	if best.covered == len(items) {
+		sb.WriteString(T("report.best_store_champion", best.store.Name, best.store.Endereco, best.total, len(items)))
+		sb.WriteString(describeStoreContact(best.store, ""))
 		sb.WriteString("\n")
+	} else {
+		sb.WriteString(T("report.best_store_partial", len(items)))
+	}
+
+	sb.WriteString(T("report.best_store_ranking"))
+	for idx, st := range ranking {
+		sb.WriteString(T("report.best_store_line", idx+1, st.store.Name, st.covered, len(items), st.total))
+		if len(st.missing) > 0 {
+			sb.WriteString(T("report.best_store_missing", strings.Join(st.missing, ", ")))
+		}
 	}
 
 	return sb.String()
@@ -0,0 +1,620 @@
+package main
+
+import (
+	"bytes"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/data/binding"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestComputeTotalCost(t *testing.T) {
+	tests := []struct {
+		name        string
+		quote       Quote
+		requiredQty float64
+		want        float64
+	}{
+		{
+			name:        "embalagem na unidade padrão",
+			quote:       Quote{Price: 50, PackagingSize: 5, ConversionFactor: 1},
+			requiredQty: 10,
+			want:        100,
+		},
+		{
+			name:        "embalagem convertida",
+			quote:       Quote{Price: 12, PackagingSize: 500, ConversionFactor: 0.001},
+			requiredQty: 2,
+			want:        48,
+		},
+		{
+			name:        "frete por pedido",
+			quote:       Quote{Price: 20, PackagingSize: 1, ConversionFactor: 1, ShippingCost: 15},
+			requiredQty: 3,
+			want:        75,
+		},
+		{
+			name:        "frete por unidade padrão",
+			quote:       Quote{Price: 20, PackagingSize: 1, ConversionFactor: 1, ShippingCost: 2, ShippingPerUnit: true},
+			requiredQty: 3,
+			want:        66,
+		},
+		{
+			name:        "quantidade zero paga só o frete por pedido",
+			quote:       Quote{Price: 20, PackagingSize: 1, ConversionFactor: 1, ShippingCost: 15},
+			requiredQty: 0,
+			want:        15,
+		},
+		{
+			name: "faixa de desconto aplicável",
+			quote: Quote{Price: 10, PackagingSize: 1, ConversionFactor: 1, Tiers: []PriceTier{
+				{MinQuantity: 10, Price: 9},
+				{MinQuantity: 50, Price: 8},
+			}},
+			requiredQty: 20,
+			want:        180,
+		},
+		{
+			name: "abaixo da menor faixa usa o preço base",
+			quote: Quote{Price: 10, PackagingSize: 1, ConversionFactor: 1, Tiers: []PriceTier{
+				{MinQuantity: 10, Price: 9},
+			}},
+			requiredQty: 5,
+			want:        50,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := computeTotalCost(tt.quote, tt.requiredQty); !approxEqual(got, tt.want) {
+				t.Errorf("computeTotalCost() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeTotalCostZeroDivisor(t *testing.T) {
+	quotes := []Quote{
+		{Price: 10, PackagingSize: 0, ConversionFactor: 1},
+		{Price: 10, PackagingSize: 5, ConversionFactor: 0},
+	}
+	for _, q := range quotes {
+		if got := computeTotalCost(q, 10); !math.IsInf(got, 1) {
+			t.Errorf("computeTotalCost(%+v) = %v, want +Inf", q, got)
+		}
+	}
+}
+
+func TestQuoteTotalCostZeroDivisor(t *testing.T) {
+	q := Quote{Price: 10, PackagingSize: 0, ConversionFactor: 1, Currency: defaultCurrency}
+	if _, err := quoteTotalCost(q, 10); err == nil {
+		t.Error("quoteTotalCost() com divisor zero deveria retornar erro")
+	}
+}
+
+func TestSortByCostTies(t *testing.T) {
+	older := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)
+	quote := func(id, storeID uint, date time.Time) Quote {
+		q := Quote{StoreID: storeID, Date: date}
+		q.ID = id
+		return q
+	}
+
+	tests := []struct {
+		name        string
+		costs       []quoteCost
+		withContact map[uint]bool
+		wantOrder   []uint
+	}{
+		{
+			name: "menor custo vence",
+			costs: []quoteCost{
+				{quote: quote(1, 1, newer), cost: 100},
+				{quote: quote(2, 2, older), cost: 99.99},
+			},
+			wantOrder: []uint{2, 1},
+		},
+		{
+			name: "empate ao centavo prefere loja com contato",
+			costs: []quoteCost{
+				{quote: quote(1, 1, newer), cost: 100.001},
+				{quote: quote(2, 2, older), cost: 99.999},
+			},
+			withContact: map[uint]bool{1: true},
+			wantOrder:   []uint{1, 2},
+		},
+		{
+			name: "empate sem contato prefere cotação mais recente",
+			costs: []quoteCost{
+				{quote: quote(1, 1, older), cost: 100},
+				{quote: quote(2, 2, newer), cost: 100},
+			},
+			wantOrder: []uint{2, 1},
+		},
+		{
+			name: "empate completo mantém a ordem de carga",
+			costs: []quoteCost{
+				{quote: quote(1, 1, older), cost: 100},
+				{quote: quote(2, 2, older), cost: 100},
+			},
+			wantOrder: []uint{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sortByCost(tt.costs, tt.withContact)
+			for i, id := range tt.wantOrder {
+				if tt.costs[i].quote.ID != id {
+					t.Fatalf("posição %d: cotação %d, want %d", i, tt.costs[i].quote.ID, id)
+				}
+			}
+			if tied := tiedWithWinner(tt.costs); len(tied) > 1 && describeTie(tied) == "" {
+				t.Error("describeTie() vazio para empate")
+			}
+		})
+	}
+}
+
+func newTestQuoteRepository(t *testing.T) (QuoteRepository, Product, Store) {
+	t.Helper()
+	conn, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("abrir sqlite em memória: %v", err)
+	}
+	if err := conn.AutoMigrate(&User{}, &Product{}, &Store{}, &StoreContact{}, &Quote{}, &PriceTier{}); err != nil {
+		t.Fatalf("migração: %v", err)
+	}
+	product := Product{Name: "Farinha", StandardUnit: "KG", Category: defaultCategory}
+	store := Store{Name: "Loja A", Endereco: "Rua 1"}
+	if err := conn.Create(&product).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.Create(&store).Error; err != nil {
+		t.Fatal(err)
+	}
+	return newGormQuoteRepository(conn), product, store
+}
+
+func TestGormQuoteRepository(t *testing.T) {
+	repo, product, store := newTestQuoteRepository(t)
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	quote := Quote{ProductID: product.ID, StoreID: store.ID, Price: 10, Currency: defaultCurrency, PackagingSize: 1, PackagingUnit: "KG", ConversionFactor: 1, Date: day}
+	if err := repo.Create(&quote, nil); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	found, err := repo.FindByDate(product.ID, day, day)
+	if err != nil || len(found) != 1 {
+		t.Fatalf("FindByDate = %d cotações, err %v; want 1", len(found), err)
+	}
+	if found[0].Store.Name != store.Name {
+		t.Errorf("FindByDate não carregou a loja: %+v", found[0].Store)
+	}
+	if other, _ := repo.FindByDate(product.ID, day.AddDate(0, 0, 1), day.AddDate(0, 0, 2)); len(other) != 0 {
+		t.Errorf("FindByDate fora do período = %d cotações, want 0", len(other))
+	}
+
+	duplicate := quote
+	duplicate.ID = 0
+	duplicate.Price = 9
+	existing, ok := repo.FindDuplicate(duplicate)
+	if !ok || existing.ID != quote.ID {
+		t.Fatalf("FindDuplicate = %d, %v; want %d", existing.ID, ok, quote.ID)
+	}
+	if err := repo.Create(&duplicate, &existing); err != nil {
+		t.Fatalf("Create com substituição: %v", err)
+	}
+	if _, err := repo.FindByID(quote.ID); err == nil {
+		t.Error("cotação substituída ainda encontrada")
+	}
+
+	duplicate.Price = 8
+	if err := repo.Update(&duplicate, nil); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if got, _ := repo.FindByID(duplicate.ID); got.Price != 8 {
+		t.Errorf("Update: preço = %v, want 8", got.Price)
+	}
+
+	if err := repo.Delete(&duplicate); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if found, _ := repo.FindByDate(product.ID, day, day); len(found) != 0 {
+		t.Errorf("FindByDate após Delete = %d cotações, want 0", len(found))
+	}
+}
+
+func TestValidateEmail(t *testing.T) {
+	valid := []string{"ana@empresa.com.br", "  joao.silva+compras@loja.com ", "x@sub-dominio.io"}
+	for _, email := range valid {
+		if _, err := validateEmail(email); err != nil {
+			t.Errorf("validateEmail(%q) = %v, want nil", email, err)
+		}
+	}
+	invalid := []string{"", "a@.", "a@b", "a@.com", "a@b..com", "a@-b.com", "@empresa.com", "ana empresa.com", "Ana <ana@empresa.com>", "ana@empresa.com, bia@empresa.com"}
+	for _, email := range invalid {
+		if _, err := validateEmail(email); err == nil {
+			t.Errorf("validateEmail(%q) = nil, want erro", email)
+		}
+	}
+}
+
+func TestValidateLength(t *testing.T) {
+	if err := validateLength("Nome", strings.Repeat("ã", maxNameLength), maxNameLength); err != nil {
+		t.Errorf("validateLength no limite = %v, want nil", err)
+	}
+	if err := validateLength("Nome", strings.Repeat("a", maxNameLength+1), maxNameLength); err == nil {
+		t.Error("validateLength acima do limite = nil, want erro")
+	}
+}
+
+func TestConvertWithDensity(t *testing.T) {
+	oil := Product{StandardUnit: "KG", Density: 0.92}
+	if got, err := convertForProduct(2, "LT", oil); err != nil || !approxEqual(got, 1.84) {
+		t.Errorf("convertForProduct(2 LT) = %v, %v; want 1.84", got, err)
+	}
+	if got, err := convertForProduct(920, "G", Product{StandardUnit: "L", Density: 0.92}); err != nil || !approxEqual(got, 1) {
+		t.Errorf("convertForProduct(920 G) = %v, %v; want 1", got, err)
+	}
+	if _, err := convertForProduct(2, "LT", Product{StandardUnit: "KG"}); err == nil {
+		t.Error("convertForProduct sem densidade deveria manter a incompatibilidade")
+	}
+	if _, err := convertForProduct(2, "UN", oil); err == nil {
+		t.Error("convertForProduct de unidade para massa deveria falhar")
+	}
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	r, err := openRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"primeira\n", "segunda\n", "terceira\n"} {
+		if _, err := r.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r.file.Close()
+	for name, want := range map[string]string{path: "terceira\n", path + ".1": "segunda\n", path + ".2": "primeira\n"} {
+		got, err := os.ReadFile(name)
+		if err != nil || string(got) != want {
+			t.Errorf("%s = %q, %v; want %q", filepath.Base(name), got, err, want)
+		}
+	}
+}
+
+func TestFilterByDelivery(t *testing.T) {
+	days := func(n int) *int { return &n }
+	quotes := []Quote{{DeliveryDays: days(3)}, {DeliveryDays: days(10)}, {}}
+	if within, late := filterByDelivery(quotes, nil); len(within) != 3 || len(late) != 0 {
+		t.Errorf("sem limite: %d dentro, %d fora; want 3, 0", len(within), len(late))
+	}
+	within, late := filterByDelivery(quotes, days(5))
+	if len(within) != 1 || *within[0].DeliveryDays != 3 {
+		t.Errorf("limite 5: dentro = %+v, want apenas prazo 3", within)
+	}
+	if len(late) != 2 {
+		t.Errorf("limite 5: %d fora, want 2 (prazo longo e desconhecido)", len(late))
+	}
+}
+
+func TestRankByScore(t *testing.T) {
+	days := func(n int) *int { return &n }
+	quote := func(id uint, delivery *int, minOrder float64) Quote {
+		q := Quote{DeliveryDays: delivery, MinOrderQuantity: minOrder}
+		q.ID = id
+		return q
+	}
+	costs := []quoteCost{
+		{quote: quote(1, days(30), 0), productCost: 100, cost: 100},
+		{quote: quote(2, days(2), 0), productCost: 104, cost: 104},
+		{quote: quote(3, nil, 50), productCost: 102, cost: 102},
+	}
+
+	byPrice := rankByScore(costs, 10, scoreWeights{price: 1})
+	if byPrice[0].quote.ID != 1 {
+		t.Errorf("só preço: vencedor = %d, want 1", byPrice[0].quote.ID)
+	}
+	if !approxEqual(byPrice[0].score, 100) {
+		t.Errorf("só preço: score do mais barato = %v, want 100", byPrice[0].score)
+	}
+
+	byDelivery := rankByScore(costs, 10, scoreWeights{price: 1, delivery: 1})
+	if byDelivery[0].quote.ID != 2 {
+		t.Errorf("preço e prazo: vencedor = %d, want 2", byDelivery[0].quote.ID)
+	}
+	if last := byDelivery[len(byDelivery)-1]; last.quote.ID != 3 || last.deliveryScore != 0 || last.meetsMinimum {
+		t.Errorf("prazo desconhecido e mínimo não atendido deveria ficar em último: %+v", last)
+	}
+
+	withShipping := []quoteCost{
+		{quote: quote(1, days(5), 0), productCost: 1000, shipping: 20, cost: 1020},
+		{quote: quote(2, days(5), 0), productCost: 1040, cost: 1040},
+	}
+	if byDefault := rankByScore(withShipping, 10, defaultScoreWeights); byDefault[0].quote.ID != 1 {
+		t.Errorf("frete de R$ 20 não deveria vencer preço R$ 40 menor: vencedor = %d, want 1 (%+v)", byDefault[0].quote.ID, byDefault)
+	}
+
+	if rankByScore(costs, 10, scoreWeights{}) != nil {
+		t.Error("pesos zerados deveriam retornar nil")
+	}
+}
+
+func TestGlobalSearchMatching(t *testing.T) {
+	if !searchMatches("Açúcar Cristal", "acucar") || !searchMatches("ACUCAR", "açúcar") {
+		t.Error("searchMatches deveria ignorar caixa e acentos")
+	}
+	if searchMatches("Adubo", "acucar") {
+		t.Error("searchMatches encontrou termo inexistente")
+	}
+	if got, want := likeCandidatePattern(" Ureia 50%"), `%_r___ 50\%%`; got != want {
+		t.Errorf("likeCandidatePattern = %q, want %q", got, want)
+	}
+}
+
+func TestAccentInsensitiveFilterAndSort(t *testing.T) {
+	if !matchesFilter("Açúcar Cristal", "acucar") || !matchesFilter("Calcário Dolomítico", "CALCARIO") {
+		t.Error("matchesFilter deveria ignorar acentos e caixa")
+	}
+	names := []string{"Zinco", "Óleo Mineral", "adubo", "Ácido Bórico", "Uréia"}
+	sortByName(names, func(s string) string { return s }, sortNameAsc)
+	want := []string{"Ácido Bórico", "adubo", "Óleo Mineral", "Uréia", "Zinco"}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("sortByName = %v, want %v", names, want)
+		}
+	}
+}
+
+func TestExchangeRateUsesCacheOrManualRate(t *testing.T) {
+	t.Setenv("USD_BRL_RATE", "5.5")
+	rateCacheMu.Lock()
+	delete(rateCache, rateKey("USD", "BRL"))
+	rateCacheMu.Unlock()
+
+	if rate, err := exchangeRate("USD"); err != nil || !approxEqual(rate, 5.5) {
+		t.Fatalf("sem cache deveria usar a taxa manual, obteve %v, %v", rate, err)
+	}
+	storeExchangeRate("usd", "brl", 5.1)
+	if rate, err := exchangeRate("USD"); err != nil || !approxEqual(rate, 5.1) {
+		t.Fatalf("deveria usar a taxa em cache, obteve %v, %v", rate, err)
+	}
+}
+
+func TestFetchExchangeRateAvoidsNetwork(t *testing.T) {
+	key := rateKey("EUR", "BRL")
+	t.Cleanup(func() {
+		rateCacheMu.Lock()
+		delete(rateCache, key)
+		delete(rateFailures, key)
+		rateCacheMu.Unlock()
+	})
+
+	rateCacheMu.Lock()
+	rateFailures[key] = time.Now()
+	rateCacheMu.Unlock()
+	if _, err := fetchExchangeRate("EUR", "BRL"); err == nil {
+		t.Fatal("logo após uma falha não deveria consultar a API de novo")
+	}
+
+	storeExchangeRate("EUR", "BRL", 6.2)
+	if rate, err := fetchExchangeRate("EUR", "BRL"); err != nil || !approxEqual(rate, 6.2) {
+		t.Fatalf("deveria usar a taxa em cache, obteve %v, %v", rate, err)
+	}
+}
+
+func useTestDatabase(t *testing.T) string {
+	t.Helper()
+	prevDB, prevDialector, prevDriver, prevPath := db, dbDialector, dbDriver, sqlitePath
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+		db, dbDialector, dbDriver, sqlitePath = prevDB, prevDialector, prevDriver, prevPath
+	})
+
+	dir := t.TempDir()
+	sqlitePath = filepath.Join(dir, "cotacao.db")
+	dbDriver = "sqlite"
+	dbDialector = sqlite.Open(sqlitePath)
+	var err error
+	if db, err = openWithRetry(); err != nil {
+		t.Fatal(err)
+	}
+	if err := migrateDatabase(); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestSQLiteRestore(t *testing.T) {
+	dir := useTestDatabase(t)
+	db.Create(&Product{Name: "Farinha", StandardUnit: "KG", Category: defaultCategory})
+
+	var backup bytes.Buffer
+	if err := backupDatabase(&backup); err != nil {
+		t.Fatalf("backupDatabase: %v", err)
+	}
+	db.Create(&Product{Name: "Açúcar", StandardUnit: "KG", Category: defaultCategory})
+
+	if _, err := restoreDatabase(strings.NewReader("não é um banco")); err == nil {
+		t.Error("restoreDatabase aceitou arquivo inválido")
+	}
+	staged, err := restoreDatabase(&backup)
+	if err != nil {
+		t.Fatalf("restoreDatabase: %v", err)
+	}
+	if err := finishRestore(staged); err != nil {
+		t.Fatalf("finishRestore: %v", err)
+	}
+
+	var count int64
+	db.Model(&Product{}).Count(&count)
+	if count != 1 {
+		t.Errorf("produtos após restaurar = %d, want 1", count)
+	}
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		if strings.Contains(e.Name(), ".restore-") {
+			t.Errorf("arquivo temporário não removido: %s", e.Name())
+		}
+	}
+}
+
+func TestImportJSONRestoresDeletedNames(t *testing.T) {
+	useTestDatabase(t)
+	product := Product{Name: "Farinha", StandardUnit: "KG", Category: defaultCategory}
+	db.Create(&product)
+	db.Delete(&product)
+
+	summary, err := importJSONData(exportData{
+		Version:  exportVersion,
+		Products: []exportProduct{{ID: 7, Name: "Farinha", StandardUnit: "KG"}},
+	})
+	if err != nil {
+		t.Fatalf("importJSONData: %v", err)
+	}
+	if summary.productsReused != 1 || summary.restored != 1 {
+		t.Errorf("resumo = %+v, want 1 reaproveitado e 1 restaurado", summary)
+	}
+	var restored Product
+	if err := db.First(&restored, product.ID).Error; err != nil {
+		t.Errorf("produto não saiu da lixeira: %v", err)
+	}
+}
+
+func TestListRefresh(t *testing.T) {
+	data := binding.NewStringList()
+	var target []int
+	loaded := false
+	load, apply := listRefresh(data, &target, func() ([]int, []string) {
+		loaded = true
+		return []int{1, 2}, []string{"um", "dois"}
+	})
+	if loaded || target != nil {
+		t.Fatal("listRefresh não deve carregar antes de load")
+	}
+	load()
+	if target != nil {
+		t.Fatal("load não deve alterar o destino, apenas apply")
+	}
+	apply()
+	if lines, _ := data.Get(); len(target) != 2 || len(lines) != 2 || lines[1] != "dois" {
+		t.Errorf("apply = %v, %v", target, lines)
+	}
+}
+
+func TestQuotePageSearchIgnoresAccents(t *testing.T) {
+	useTestDatabase(t)
+	store := Store{Name: "Loja A", Endereco: "Rua 1"}
+	db.Create(&store)
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	for _, name := range []string{"Açúcar Cristal", "Farinha"} {
+		product := Product{Name: name, StandardUnit: "KG", Category: defaultCategory}
+		db.Create(&product)
+		db.Create(&Quote{ProductID: product.ID, StoreID: store.ID, Price: 10, Currency: defaultCurrency, PackagingSize: 1, PackagingUnit: "KG", ConversionFactor: 1, Date: day})
+	}
+
+	result := loadQuotePage(0, 10, quoteListFilter{productName: "acucar"}, sortInsertion)
+	if result.total != 1 || len(result.quotes) != 1 || result.quotes[0].Product.Name != "Açúcar Cristal" {
+		t.Errorf("busca 'acucar' = %d cotações (%v), want só Açúcar Cristal", result.total, result.lines)
+	}
+}
+
+func TestQuotePageSortsByUnitPriceInDatabase(t *testing.T) {
+	useTestDatabase(t)
+	t.Setenv("USD_BRL_RATE", "5.5")
+	clearRate := func() {
+		rateCacheMu.Lock()
+		delete(rateCache, rateKey("USD", "BRL"))
+		rateCacheMu.Unlock()
+	}
+	clearRate()
+	t.Cleanup(clearRate)
+
+	store := Store{Name: "Loja A", Endereco: "Rua 1"}
+	db.Create(&store)
+	product := Product{Name: "Farinha", StandardUnit: "KG", Category: defaultCategory}
+	db.Create(&product)
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	create := func(price, size float64, currency string) uint {
+		q := Quote{ProductID: product.ID, StoreID: store.ID, Price: price, Currency: currency, PackagingSize: size, PackagingUnit: "KG", ConversionFactor: 1, Date: day}
+		db.Create(&q)
+		return q.ID
+	}
+	ten := create(10, 1, defaultCurrency)
+	six := create(30, 5, defaultCurrency)
+	eleven := create(2, 1, "USD")
+	invalid := create(5, 0, defaultCurrency)
+
+	ids := func(page, size int, order string) []uint {
+		var got []uint
+		for _, q := range loadQuotePage(page, size, quoteListFilter{}, order).quotes {
+			got = append(got, q.ID)
+		}
+		return got
+	}
+	if got, want := ids(0, 10, sortUnitPriceAsc), []uint{six, ten, eleven, invalid}; !slices.Equal(got, want) {
+		t.Errorf("ordem crescente = %v, want %v", got, want)
+	}
+	if got, want := ids(0, 10, sortUnitPriceDesc), []uint{eleven, ten, six, invalid}; !slices.Equal(got, want) {
+		t.Errorf("ordem decrescente = %v, want %v", got, want)
+	}
+	if got, want := ids(1, 2, sortUnitPriceAsc), []uint{eleven, invalid}; !slices.Equal(got, want) {
+		t.Errorf("segunda página = %v, want %v", got, want)
+	}
+}
+
+func TestLocalesHaveSameKeys(t *testing.T) {
+	pt, es := messages[langPortuguese], messages[langSpanish]
+	if len(pt) == 0 || len(es) == 0 {
+		t.Fatalf("traduções não carregadas: pt-BR=%d es=%d", len(pt), len(es))
+	}
+	for key, msg := range pt {
+		other, ok := es[key]
+		if !ok {
+			t.Errorf("chave %q ausente em es", key)
+			continue
+		}
+		if strings.Count(msg, "%") != strings.Count(other, "%") {
+			t.Errorf("chave %q com formatos diferentes: %q e %q", key, msg, other)
+		}
+	}
+	for key := range es {
+		if _, ok := pt[key]; !ok {
+			t.Errorf("chave %q ausente em pt-BR", key)
+		}
+	}
+}
+
+func TestOptionKeysTranslated(t *testing.T) {
+	var keys []string
+	for _, options := range [][]string{nameSortOptions, quoteSortOptions, prescriptionSortOptions, themeOptions, shippingModes} {
+		keys = append(keys, options...)
+	}
+	keys = append(keys, allGroupsOption, allCategoriesOption, defaultCategoryKey, tieBreakCriteria)
+	for _, key := range keys {
+		if _, ok := messages[langPortuguese][key]; !ok {
+			t.Errorf("opção %q sem tradução", key)
+		}
+	}
+	if got := optionKey(quoteSortOptions, T(sortUnitPriceDesc)); got != sortUnitPriceDesc {
+		t.Errorf("optionKey = %q, want %q", got, sortUnitPriceDesc)
+	}
+	if got := categoryFromLabel(categoryLabel(defaultCategory)); got != defaultCategory {
+		t.Errorf("categoria padrão = %q, want %q", got, defaultCategory)
+	}
+}
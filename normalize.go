@@ -0,0 +1,30 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+func removeDiacritics(s string) string {
+	var sb strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		if !unicode.Is(unicode.Mn, r) {
+			sb.WriteRune(r)
+		}
+	}
+	return norm.NFC.String(sb.String())
+}
+
+func foldText(s string) string {
+	return strings.ToLower(removeDiacritics(s))
+}
+
+func lessFolded(a, b string) bool {
+	fa, fb := foldText(a), foldText(b)
+	if fa != fb {
+		return fa < fb
+	}
+	return a < b
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+const minPasswordLength = 8
+
+func passwordMissing(password string) []string {
+	var hasLetter, hasDigit, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSpecial = true
+		}
+	}
+	var missing []string
+	if len([]rune(password)) < minPasswordLength {
+		missing = append(missing, T("password.missing_length", minPasswordLength))
+	}
+	if !hasLetter {
+		missing = append(missing, T("password.missing_letter"))
+	}
+	if !hasDigit {
+		missing = append(missing, T("password.missing_number"))
+	}
+	if !hasSpecial {
+		missing = append(missing, T("password.missing_special"))
+	}
+	return missing
+}
+
+func validatePasswordStrength(password string) error {
+	if missing := passwordMissing(password); len(missing) > 0 {
+		return errors.New(T("password.weak", minPasswordLength))
+	}
+	return nil
+}
+
+func passwordStrengthIndicator(entry *widget.Entry) fyne.CanvasObject {
+	bar := widget.NewProgressBar()
+	bar.TextFormatter = func() string { return "" }
+	label := widget.NewLabel("")
+	update := func(password string) {
+		if password == "" {
+			bar.SetValue(0)
+			label.SetText("")
+			return
+		}
+		missing := passwordMissing(password)
+		bar.SetValue(float64(4-len(missing)) / 4)
+		strength := T("password.strength_strong")
+		switch {
+		case len(missing) >= 2:
+			strength = T("password.strength_weak")
+		case len(missing) == 1:
+			strength = T("password.strength_medium")
+		}
+		text := T("password.strength", strength)
+		if len(missing) > 0 {
+			text += " - " + T("password.missing", strings.Join(missing, ", "))
+		}
+		label.SetText(text)
+	}
+	previous := entry.OnChanged
+	entry.OnChanged = func(s string) {
+		if previous != nil {
+			previous(s)
+		}
+		update(s)
+	}
+	return container.NewVBox(bar, label)
+}
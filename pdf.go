@@ -0,0 +1,44 @@
+package main
+
+import (
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+)
+
+const defaultCompanyName = "Sistema de Cotação de Produtos"
+
+func companyName() string {
+	if name := strings.TrimSpace(os.Getenv("COMPANY_NAME")); name != "" {
+		return name
+	}
+	return defaultCompanyName
+}
+
+func writeReportPDF(out io.Writer, title, report string) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.SetHeaderFunc(func() {
+		pdf.SetFont("Helvetica", "B", 12)
+		pdf.CellFormat(0, 7, tr(companyName()), "", 1, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.CellFormat(0, 6, tr(title), "", 1, "L", false, 0, "")
+		pdf.CellFormat(0, 6, tr(T("pdf.generated_at", time.Now().Format("2006-01-02 15:04"))), "B", 1, "L", false, 0, "")
+		pdf.Ln(4)
+	})
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.CellFormat(0, 6, tr(T("pdf.page", pdf.PageNo())), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+	pdf.SetFont("Courier", "", 9)
+	for _, line := range strings.Split(report, "\n") {
+		pdf.MultiCell(0, 4.5, tr(line), "", "L", false)
+	}
+	return pdf.Output(out)
+}
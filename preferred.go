@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+const prefPreferredTolerance = "preferredTolerancePercent"
+
+var preferredTolerance float64
+
+func loadPreferredTolerance(a fyne.App) {
+	preferredTolerance = a.Preferences().FloatWithFallback(prefPreferredTolerance, 0)
+}
+
+func parseTolerance(text string) (float64, error) {
+	text = strings.TrimSpace(strings.ReplaceAll(text, ",", "."))
+	if text == "" {
+		return 0, nil
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || value < 0 || value > 100 {
+		return 0, errors.New(T("preferred.invalid_tolerance"))
+	}
+	return value, nil
+}
+
+func preferredToleranceEntry() *widget.Entry {
+	entry := newEntry()
+	entry.SetPlaceHolder(T("preferred.tolerance_placeholder"))
+	if preferredTolerance > 0 {
+		entry.SetText(formatFloat(preferredTolerance))
+	}
+	entry.Validator = func(text string) error {
+		_, err := parseTolerance(text)
+		return err
+	}
+	entry.OnChanged = func(text string) {
+		value, err := parseTolerance(text)
+		if err != nil {
+			return
+		}
+		preferredTolerance = value
+		fyne.CurrentApp().Preferences().SetFloat(prefPreferredTolerance, value)
+	}
+	return entry
+}
+
+// Com tolerância configurada, a cotação mais barata de uma loja preferencial
+// assume o primeiro lugar se o custo dela não passar do menor custo + tolerância.
+func preferPreferredStore(costs []quoteCost, tolerance float64) []quoteCost {
+	if tolerance <= 0 || len(costs) < 2 || costs[0].quote.Store.Preferred {
+		return costs
+	}
+	limit := costCents(costs[0].cost * (1 + tolerance/100))
+	for i, qc := range costs[1:] {
+		if costCents(qc.cost) > limit {
+			break
+		}
+		if !qc.quote.Store.Preferred {
+			continue
+		}
+		cheapest := costs[0]
+		qc.preferredOver = &cheapest
+		qc.tolerance = tolerance
+		reordered := append([]quoteCost{qc}, costs[:i+1]...)
+		return append(reordered, costs[i+2:]...)
+	}
+	return costs
+}
+
+func describePreference(qc quoteCost) string {
+	if qc.preferredOver == nil {
+		return ""
+	}
+	cheapest := qc.preferredOver
+	return T("preferred.chosen",
+		qc.quote.Store.Name, formatPercent(qc.cost-cheapest.cost, cheapest.cost), qc.cost-cheapest.cost, cheapest.quote.Store.Name, formatFloat(qc.tolerance))
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+)
+
+const (
+	prefWindowWidth  = "window.width"
+	prefWindowHeight = "window.height"
+	prefLastTab      = "lastTab."
+	prefReportStart  = "report.start"
+	prefReportEnd    = "report.end"
+
+	defaultWindowWidth  = 800
+	defaultWindowHeight = 600
+	minWindowWidth      = 640
+	minWindowHeight     = 480
+	maxWindowWidth      = 3840
+	maxWindowHeight     = 2160
+)
+
+func clampWindowSize(width, height float64) fyne.Size {
+	if width <= 0 || height <= 0 {
+		return fyne.NewSize(defaultWindowWidth, defaultWindowHeight)
+	}
+	width = min(max(width, minWindowWidth), maxWindowWidth)
+	height = min(max(height, minWindowHeight), maxWindowHeight)
+	return fyne.NewSize(float32(width), float32(height))
+}
+
+func restoreWindowSize(a fyne.App, w fyne.Window) {
+	prefs := a.Preferences()
+	w.Resize(clampWindowSize(
+		prefs.FloatWithFallback(prefWindowWidth, defaultWindowWidth),
+		prefs.FloatWithFallback(prefWindowHeight, defaultWindowHeight),
+	))
+}
+
+func saveWindowSize(a fyne.App, w fyne.Window) {
+	size := w.Canvas().Size()
+	if size.Width <= 0 || size.Height <= 0 {
+		return
+	}
+	a.Preferences().SetFloat(prefWindowWidth, float64(size.Width))
+	a.Preferences().SetFloat(prefWindowHeight, float64(size.Height))
+}
+
+func lastTabKey() string {
+	if currentUser == nil {
+		return prefLastTab
+	}
+	return prefLastTab + currentUser.Username
+}
+
+// A aba é gravada pela chave estável, e não pelo título, para sobreviver à
+// troca de idioma.
+func restoreLastTab(tabs *container.AppTabs, tabKeys map[*container.TabItem]string) {
+	last := fyne.CurrentApp().Preferences().String(lastTabKey())
+	for _, item := range tabs.Items {
+		if tabKeys[item] == last {
+			tabs.Select(item)
+			break
+		}
+	}
+}
+
+func saveLastTab(tabKey string) {
+	fyne.CurrentApp().Preferences().SetString(lastTabKey(), tabKey)
+}
+
+func savedReportDate(key string) (time.Time, bool) {
+	t, err := time.Parse("2006-01-02", fyne.CurrentApp().Preferences().String(key))
+	if err != nil || validateQuoteDate(t) != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+func restoreReportDates(startPicker, endPicker *DatePicker) {
+	start, ok := savedReportDate(prefReportStart)
+	if !ok {
+		start = today()
+	}
+	startPicker.SetDate(start)
+	if end, ok := savedReportDate(prefReportEnd); ok && end.After(start) {
+		endPicker.SetDate(end)
+	}
+}
+
+func saveReportDates(start, end time.Time) {
+	prefs := fyne.CurrentApp().Preferences()
+	prefs.SetString(prefReportStart, start.Format("2006-01-02"))
+	prefs.SetString(prefReportEnd, end.Format("2006-01-02"))
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"errors"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func roleName(role string) string {
+	if role == roleAdmin {
+		return T("profile.role_admin")
+	}
+	if role == roleAuditor {
+		return T("profile.role_auditor")
+	}
+	return T("profile.role_user")
+}
+
+func profileTab(w fyne.Window, onSaved func()) fyne.CanvasObject {
+	usernameLabel := widget.NewLabel("")
+	roleLabel := widget.NewLabel("")
+	fullNameEntry := newEntry()
+	emailEntry := newEntry()
+	fill := func() {
+		if currentUser == nil {
+			return
+		}
+		usernameLabel.SetText(currentUser.Username)
+		roleLabel.SetText(roleName(currentUser.Role))
+		fullNameEntry.SetText(currentUser.FullName)
+		emailEntry.SetText(currentUser.Email)
+	}
+	fill()
+
+	form := widget.NewForm(
+		widget.NewFormItem(T("login.username"), usernameLabel),
+		widget.NewFormItem(T("profile.role"), roleLabel),
+		widget.NewFormItem(T("register.full_name"), fullNameEntry),
+		widget.NewFormItem(T("register.email"), emailEntry),
+	)
+
+	saveBtn := newButton(T("profile.save"), func() {
+		if currentUser == nil {
+			dialog.ShowError(errors.New(T("password.no_user")), w)
+			return
+		}
+		fullName := strings.TrimSpace(fullNameEntry.Text)
+		if fullName == "" || strings.TrimSpace(emailEntry.Text) == "" {
+			dialog.ShowError(errors.New(T("common.all_fields_required")), w)
+			return
+		}
+		email, err := validateEmail(emailEntry.Text)
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		var user User
+		if err := db.First(&user, currentUser.ID).Error; err != nil {
+			dialog.ShowError(errors.New(T("common.user_not_found")), w)
+			return
+		}
+		if user.FullName == fullName && user.Email == email {
+			showNoChanges(w)
+			return
+		}
+		var existingUser User
+		if err := db.Where("email = ? AND id <> ?", email, user.ID).First(&existingUser).Error; err == nil {
+			dialog.ShowError(errors.New(T("register.email_exists")), w)
+			return
+		}
+		user.FullName = fullName
+		user.Email = email
+		if err := db.Save(&user).Error; err != nil {
+			showDBError(err, w)
+			return
+		}
+		recordAudit(auditUpdate, "Usuário", user.ID, user.Username+" (perfil)")
+		setCurrentUser(user)
+		fill()
+		onSaved()
+		dialog.ShowInformation(T("common.success"), T("profile.success"), w)
+	})
+
+	deleteBtn := newButton(T("profile.delete"), func() {
+		deleteOwnAccount(w)
+	})
+	deleteBtn.Importance = widget.DangerImportance
+
+	return container.NewVBox(form, saveBtn, widget.NewSeparator(), deleteBtn)
+}
+
+func deleteOwnAccount(w fyne.Window) {
+	if currentUser == nil {
+		dialog.ShowError(errors.New(T("password.no_user")), w)
+		return
+	}
+	if currentUser.Role == roleAdmin {
+		var adminCount int64
+		db.Model(&User{}).Where("role = ?", roleAdmin).Count(&adminCount)
+		if adminCount <= 1 {
+			dialog.ShowError(errors.New(T("profile.delete_last_admin")), w)
+			return
+		}
+	}
+
+	warning := widget.NewLabel(T("profile.delete_warning", currentUser.Username))
+	warning.Wrapping = fyne.TextWrapWord
+	passwordEntry := newPasswordEntry()
+	items := []*widget.FormItem{
+		widget.NewFormItem("", warning),
+		widget.NewFormItem(T("login.password"), passwordEntry),
+	}
+	dlg := dialog.NewForm(T("profile.delete_title"), T("profile.delete_confirm"), T("common.cancel"), items, func(ok bool) {
+		if !ok {
+			return
+		}
+		var user User
+		if err := db.First(&user, currentUser.ID).Error; err != nil {
+			dialog.ShowError(errors.New(T("common.user_not_found")), w)
+			return
+		}
+		if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(passwordEntry.Text)); err != nil {
+			dialog.ShowError(errors.New(T("profile.delete_wrong_password")), w)
+			return
+		}
+		if err := db.Delete(&user).Error; err != nil {
+			showDBError(err, w)
+			return
+		}
+		recordAudit(auditDelete, "Usuário", user.ID, user.Username+" (exclusão da própria conta)")
+		logout(w)
+		dialog.ShowInformation(T("profile.delete_title"), T("profile.deleted"), w)
+	}, w)
+	dlg.Resize(fyne.NewSize(460, 260))
+	dlg.Show()
+}
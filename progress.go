@@ -0,0 +1,22 @@
+package main
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+func runWithProgress(w fyne.Window, message string, work func(), done func()) {
+	bar := widget.NewProgressBarInfinite()
+	progress := dialog.NewCustomWithoutButtons(T("progress.wait"), container.NewVBox(widget.NewLabel(message), bar), w)
+	progress.Show()
+	go func() {
+		work()
+		fyne.Do(func() {
+			bar.Stop()
+			progress.Hide()
+			done()
+		})
+	}()
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"errors"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+func selectOptionByID(sel *widget.Select, options map[string]uint, id uint) {
+	for opt, optID := range options {
+		if optID == id {
+			sel.SetSelected(opt)
+			return
+		}
+	}
+}
+
+func quickAddProduct(w fyne.Window, onCreated func(Product)) {
+	nameEntry := newEntry()
+	limitLength(nameEntry, T("product.name"), maxNameLength)
+	unitSelect := widget.NewSelect(loadUnitOptions(), nil)
+	categorySelect := widget.NewSelect(loadCategoryOptions(), nil)
+	categorySelect.SetSelected(categoryLabel(defaultCategory))
+	items := []*widget.FormItem{
+		widget.NewFormItem(T("product.name"), nameEntry),
+		widget.NewFormItem(T("product.unit"), unitSelectField(w, unitSelect)),
+		widget.NewFormItem(T("product.category"), categorySelect),
+	}
+	dlg := dialog.NewForm(T("quickadd.product_title"), T("common.save"), T("common.cancel"), items, func(ok bool) {
+		if !ok {
+			return
+		}
+		name := strings.TrimSpace(nameEntry.Text)
+		if name == "" || unitSelect.Selected == "" {
+			dialog.ShowError(errors.New(T("product.name_unit_required")), w)
+			return
+		}
+		if err := checkLengths(nameEntry); err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		product := Product{Name: name, StandardUnit: unitSelect.Selected, Category: categoryFromLabel(categorySelect.Selected)}
+		if err := db.Create(&product).Error; err != nil {
+			showDBError(err, w)
+			return
+		}
+		recordAudit(auditCreate, "Produto", product.ID, product.Name+" (cadastro rápido)")
+		invalidateProductCache()
+		onCreated(product)
+	}, w)
+	dlg.Resize(fyne.NewSize(420, 240))
+	dlg.Show()
+}
+
+func quickAddStore(w fyne.Window, onCreated func(Store)) {
+	nameEntry := newEntry()
+	enderecoEntry := newEntry()
+	telefoneEntry := newEntry()
+	applyPhoneMask(telefoneEntry)
+	limitLength(nameEntry, T("quickadd.store_name_field"), maxNameLength)
+	limitLength(enderecoEntry, T("quickadd.address"), maxAddressLength)
+	items := []*widget.FormItem{
+		widget.NewFormItem(T("quickadd.store_name"), nameEntry),
+		widget.NewFormItem(T("quickadd.address"), enderecoEntry),
+		widget.NewFormItem(T("quickadd.main_phone"), telefoneEntry),
+	}
+	dlg := dialog.NewForm(T("quickadd.store_title"), T("common.save"), T("common.cancel"), items, func(ok bool) {
+		if !ok {
+			return
+		}
+		name, endereco := strings.TrimSpace(nameEntry.Text), strings.TrimSpace(enderecoEntry.Text)
+		if name == "" || endereco == "" {
+			dialog.ShowError(errors.New(T("quickadd.store_required")), w)
+			return
+		}
+		if err := checkLengths(nameEntry, enderecoEntry); err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		telefone, err := validatePhone(telefoneEntry.Text)
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		store := Store{Name: name, Endereco: endereco}
+		if telefone != "" {
+			store.Contacts = []StoreContact{{Type: contactPrincipal, Phone: telefone}}
+		}
+		if err := db.Create(&store).Error; err != nil {
+			showDBError(err, w)
+			return
+		}
+		recordAudit(auditCreate, "Loja", store.ID, store.Name+" (cadastro rápido)")
+		invalidateStoreCache()
+		onCreated(store)
+	}, w)
+	dlg.Resize(fyne.NewSize(420, 240))
+	dlg.Show()
+}
+
+func withQuickAdd(sel *widget.Select, label string, add func()) fyne.CanvasObject {
+	if !isAdmin() {
+		return sel
+	}
+	return container.NewBorder(nil, nil, nil, newButton(label, add), sel)
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+const allProductsOption = "Todos os produtos"
+const allStoresOption = "Todas as lojas"
+
+func searchQuotes(productID, storeID uint) []Quote {
+	var quotes []Quote
+	query := db.Preload("Product").Preload("Store")
+	if productID != 0 {
+		query = query.Where("product_id = ?", productID)
+	}
+	if storeID != 0 {
+		query = query.Where("store_id = ?", storeID)
+	}
+	query.Order("date desc, id").Find(&quotes)
+	return quotes
+}
+
+func quoteSearchTab(w fyne.Window) fyne.CanvasObject {
+	productSelect := widget.NewSelect(append([]string{allProductsOption}, productOptions...), func(s string) {})
+	productSelect.SetSelected(allProductsOption)
+	storeSelect := widget.NewSelect(append([]string{allStoresOption}, storeOptions...), func(s string) {})
+	storeSelect.SetSelected(allStoresOption)
+	summaryLabel := widget.NewLabel("")
+
+	var results []Quote
+	headers := []string{T("compare.date"), T("compare.product"), T("compare.store"), T("compare.price"), T("quotesearch.packaging"), T("compare.unit_price"), T("quotesearch.valid_until")}
+
+	table := widget.NewTable(
+		func() (int, int) {
+			return len(results) + 1, len(headers)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("template template")
+		},
+		func(id widget.TableCellID, co fyne.CanvasObject) {
+			label := co.(*widget.Label)
+			label.TextStyle = fyne.TextStyle{}
+			if id.Row == 0 {
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				label.SetText(headers[id.Col])
+				return
+			}
+			q := results[id.Row-1]
+			switch id.Col {
+			case 0:
+				label.SetText(q.Date.Format("2006-01-02"))
+			case 1:
+				label.SetText(q.Product.Name)
+			case 2:
+				label.SetText(q.Store.Name)
+			case 3:
+				label.SetText(formatQuotePrice(q))
+			case 4:
+				label.SetText(fmt.Sprintf("%.2f %s", q.PackagingSize, q.PackagingUnit))
+			case 5:
+				if ppu, ok := pricePerStandardUnit(q); ok {
+					label.SetText(fmt.Sprintf("R$ %.4f / %s", ppu, q.Product.StandardUnit))
+				} else {
+					label.SetText("N/A")
+				}
+			case 6:
+				if q.ValidUntil == nil {
+					label.SetText("-")
+				} else {
+					label.SetText(q.ValidUntil.Format("2006-01-02"))
+				}
+			}
+		},
+	)
+	table.SetColumnWidth(0, 110)
+	table.SetColumnWidth(1, 200)
+	table.SetColumnWidth(2, 200)
+	table.SetColumnWidth(3, 170)
+	table.SetColumnWidth(4, 120)
+	table.SetColumnWidth(5, 200)
+	table.SetColumnWidth(6, 110)
+
+	searchBtn := newButton(T("common.search"), func() {
+		if !connectionAvailable(w) {
+			return
+		}
+		productID := productMap[productSelect.Selected]
+		storeID := storeMap[storeSelect.Selected]
+		if productID == 0 && storeID == 0 {
+			dialog.ShowError(errors.New(T("quotesearch.select_filter")), w)
+			return
+		}
+		var found []Quote
+		runWithProgress(w, T("quotesearch.searching"), func() {
+			found = searchQuotes(productID, storeID)
+		}, func() {
+			results = found
+			if len(results) == 0 {
+				summaryLabel.SetText(T("quotesearch.none"))
+			} else {
+				summaryLabel.SetText(T("history.found", len(results)))
+			}
+			table.Refresh()
+		})
+	})
+
+	refreshBtn := newButton(T("quotesearch.refresh_lists"), func() {
+		productOptions, productMap = loadProductOptions()
+		storeOptions, storeMap = loadStoreOptions()
+		productSelect.Options = append([]string{allProductsOption}, productOptions...)
+		productSelect.SetSelected(allProductsOption)
+		storeSelect.Options = append([]string{allStoresOption}, storeOptions...)
+		storeSelect.SetSelected(allStoresOption)
+	})
+
+	registerRefresh(refreshWidgets(func() {
+		productSelect.Options = append([]string{allProductsOption}, productOptions...)
+		productSelect.Refresh()
+		storeSelect.Options = append([]string{allStoresOption}, storeOptions...)
+		storeSelect.Refresh()
+	}))
+	registerRecordFocus("tab.quote_search", func(id uint) {
+		quote, err := quoteRepo.FindByID(id)
+		if err != nil {
+			showDBError(err, w)
+			return
+		}
+		refreshBtn.OnTapped()
+		selectOptionByID(productSelect, productMap, quote.ProductID)
+		selectOptionByID(storeSelect, storeMap, quote.StoreID)
+		searchBtn.OnTapped()
+	})
+
+	top := container.NewVBox(
+		widget.NewForm(
+			widget.NewFormItem(T("compare.product"), productSelect),
+			widget.NewFormItem(T("compare.store"), storeSelect),
+		),
+		container.NewHBox(searchBtn, refreshBtn),
+		summaryLabel,
+	)
+	return container.NewBorder(top, nil, nil, nil, table)
+}
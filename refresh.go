@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+)
+
+// Chamada na thread da interface para ler o estado dos widgets. Devolve load,
+// que faz as consultas em segundo plano (pode ser nil), e apply, que mostra o
+// resultado nos widgets de volta na thread da interface.
+type tabRefresh func() (load, apply func())
+
+var tabRefreshers []tabRefresh
+
+func registerRefresh(refresh tabRefresh) {
+	tabRefreshers = append(tabRefreshers, refresh)
+}
+
+func (r tabRefresh) now() {
+	load, apply := r()
+	if load != nil {
+		load()
+	}
+	apply()
+}
+
+func refreshWidgets(apply func()) tabRefresh {
+	return func() (func(), func()) { return nil, apply }
+}
+
+func listRefresh[T any](data binding.StringList, target *[]T, load func() ([]T, []string)) (func(), func()) {
+	var items []T
+	var lines []string
+	return func() {
+			items, lines = load()
+		}, func() {
+			*target = items
+			data.Set(lines)
+		}
+}
+
+func refreshAll(w fyne.Window) {
+	loads := make([]func(), len(tabRefreshers))
+	applies := make([]func(), len(tabRefreshers))
+	for i, refresh := range tabRefreshers {
+		loads[i], applies[i] = refresh()
+	}
+
+	var products, stores, groups []string
+	var productIDs, storeIDs, groupIDs map[string]uint
+	runWithProgress(w, T("refresh.progress"), func() {
+		invalidateProductCache()
+		invalidateStoreCache()
+		products, productIDs = loadProductOptions()
+		stores, storeIDs = loadStoreOptions()
+		groups, groupIDs = loadGroupOptions()
+		for _, load := range loads {
+			if load != nil {
+				load()
+			}
+		}
+	}, func() {
+		productOptions, productMap = products, productIDs
+		storeOptions, storeMap = stores, storeIDs
+		groupOptions, groupMap = groups, groupIDs
+		for _, apply := range applies {
+			apply()
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+const reportViewHeight = 240
+
+type reportView struct {
+	widget.Entry
+}
+
+func newReportView() *reportView {
+	v := &reportView{}
+	v.MultiLine = true
+	v.Wrapping = fyne.TextWrapOff
+	v.Scroll = fyne.ScrollNone
+	v.TextStyle = fyne.TextStyle{Monospace: true}
+	v.ExtendBaseWidget(v)
+	return v
+}
+
+func (v *reportView) TypedRune(rune) {}
+
+func (v *reportView) TypedKey(key *fyne.KeyEvent) {
+	switch key.Name {
+	case fyne.KeyUp, fyne.KeyDown, fyne.KeyLeft, fyne.KeyRight, fyne.KeyHome, fyne.KeyEnd, fyne.KeyPageUp, fyne.KeyPageDown:
+		v.Entry.TypedKey(key)
+	}
+}
+
+func (v *reportView) TypedShortcut(shortcut fyne.Shortcut) {
+	switch shortcut.(type) {
+	case *fyne.ShortcutCopy, *fyne.ShortcutSelectAll:
+		v.Entry.TypedShortcut(shortcut)
+	}
+}
+
+func scrollableReport(v *reportView) fyne.CanvasObject {
+	scroll := container.NewScroll(v)
+	scroll.SetMinSize(fyne.NewSize(0, reportViewHeight))
+	return scroll
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type QuoteRepository interface {
+	FindByID(id uint) (Quote, error)
+	FindByDate(productID uint, start, end time.Time) ([]Quote, error)
+	FindDuplicate(quote Quote) (Quote, bool)
+	Create(quote *Quote, replaced *Quote) error
+	Update(quote *Quote, replaced *Quote) error
+	Delete(quote *Quote) error
+}
+
+// Sem conexão própria o repositório usa a variável global db, que pode ser
+// trocada por reconexão ou restauração de backup.
+type gormQuoteRepository struct {
+	conn *gorm.DB
+}
+
+var quoteRepo QuoteRepository = gormQuoteRepository{}
+
+func newGormQuoteRepository(conn *gorm.DB) QuoteRepository {
+	return gormQuoteRepository{conn: conn}
+}
+
+func (r gormQuoteRepository) handle() *gorm.DB {
+	if r.conn != nil {
+		return r.conn
+	}
+	return db
+}
+
+func (r gormQuoteRepository) FindByID(id uint) (Quote, error) {
+	var quote Quote
+	err := r.handle().First(&quote, id).Error
+	return quote, err
+}
+
+func (r gormQuoteRepository) FindByDate(productID uint, start, end time.Time) ([]Quote, error) {
+	var quotes []Quote
+	err := r.handle().Preload("Store.Contacts").Preload("Tiers").
+		Where("product_id = ? AND date BETWEEN ? AND ?", productID, start, end).
+		Order("id").Find(&quotes).Error
+	return quotes, err
+}
+
+func (r gormQuoteRepository) FindDuplicate(quote Quote) (Quote, bool) {
+	var existing Quote
+	err := r.handle().Preload("Product").Preload("Store").
+		Where("product_id = ? AND store_id = ? AND date = ? AND id <> ?", quote.ProductID, quote.StoreID, quote.Date, quote.ID).
+		First(&existing).Error
+	return existing, err == nil
+}
+
+func (r gormQuoteRepository) Create(quote *Quote, replaced *Quote) error {
+	return r.replacing(replaced, func(tx *gorm.DB) error {
+		return tx.Create(quote).Error
+	})
+}
+
+func (r gormQuoteRepository) Update(quote *Quote, replaced *Quote) error {
+	return r.replacing(replaced, func(tx *gorm.DB) error {
+		return tx.Save(quote).Error
+	})
+}
+
+func (r gormQuoteRepository) Delete(quote *Quote) error {
+	return r.handle().Delete(quote).Error
+}
+
+func (r gormQuoteRepository) replacing(replaced *Quote, save func(tx *gorm.DB) error) error {
+	return r.handle().Transaction(func(tx *gorm.DB) error {
+		if replaced != nil {
+			if err := tx.Delete(replaced).Error; err != nil {
+				return err
+			}
+		}
+		return save(tx)
+	})
+}
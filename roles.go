@@ -0,0 +1,29 @@
+package main
+
+import "fyne.io/fyne/v2/widget"
+
+const (
+	roleUser    = "user"
+	roleAdmin   = "admin"
+	roleAuditor = "auditor"
+)
+
+var roleOptions = []string{roleUser, roleAdmin, roleAuditor}
+
+func isAuditor() bool {
+	return currentUser != nil && currentUser.Role == roleAuditor
+}
+
+func canViewAdminTabs() bool {
+	return isAdmin() || isAuditor()
+}
+
+// Auditores veem todas as abas, mas os botões que gravam dados ficam desabilitados.
+func writeActions(buttons ...*widget.Button) {
+	if !isAuditor() {
+		return
+	}
+	for _, b := range buttons {
+		b.Disable()
+	}
+}
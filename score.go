@@ -0,0 +1,207 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+type scoreWeights struct {
+	price    float64
+	shipping float64
+	delivery float64
+	minOrder float64
+}
+
+var defaultScoreWeights = scoreWeights{price: 60, shipping: 20, delivery: 15, minOrder: 5}
+
+var scoreWeightsInUse = defaultScoreWeights
+
+func (w scoreWeights) total() float64 {
+	return w.price + w.shipping + w.delivery + w.minOrder
+}
+
+type scoredQuote struct {
+	quoteCost
+	score         float64
+	priceScore    float64
+	shippingScore float64
+	deliveryScore float64
+	meetsMinimum  bool
+}
+
+// Cada critério vale de 0 a 1: o melhor valor recebe 1 e os demais perdem na
+// proporção da distância até ele, relativa à escala. Preço e frete usam a mesma
+// escala (o maior custo total), para que R$ 1 de frete pese o mesmo que R$ 1 de
+// preço. Prazo desconhecido recebe 0.
+func relativeScore(value, best, scale float64) float64 {
+	if scale <= 0 {
+		return 1
+	}
+	return 1 - (value-best)/scale
+}
+
+func rankByScore(costs []quoteCost, requiredQty float64, weights scoreWeights) []scoredQuote {
+	if len(costs) == 0 || weights.total() <= 0 {
+		return nil
+	}
+	minPrice, minShipping, maxCost := math.Inf(1), math.Inf(1), 0.0
+	minDays, maxDays := math.Inf(1), 0.0
+	for _, qc := range costs {
+		minPrice = math.Min(minPrice, qc.productCost)
+		minShipping = math.Min(minShipping, qc.shipping)
+		maxCost = math.Max(maxCost, qc.cost)
+		if qc.quote.DeliveryDays != nil {
+			days := float64(*qc.quote.DeliveryDays)
+			minDays, maxDays = math.Min(minDays, days), math.Max(maxDays, days)
+		}
+	}
+
+	ranked := make([]scoredQuote, 0, len(costs))
+	for _, qc := range costs {
+		sq := scoredQuote{
+			quoteCost:     qc,
+			priceScore:    relativeScore(qc.productCost, minPrice, maxCost),
+			shippingScore: relativeScore(qc.shipping, minShipping, maxCost),
+			meetsMinimum:  meetsMinOrder(qc.quote, requiredQty),
+		}
+		if qc.quote.DeliveryDays != nil {
+			sq.deliveryScore = relativeScore(float64(*qc.quote.DeliveryDays), minDays, maxDays)
+		}
+		minOrderScore := 0.0
+		if sq.meetsMinimum {
+			minOrderScore = 1
+		}
+		sq.score = (weights.price*sq.priceScore +
+			weights.shipping*sq.shippingScore +
+			weights.delivery*sq.deliveryScore +
+			weights.minOrder*minOrderScore) / weights.total() * 100
+		ranked = append(ranked, sq)
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return math.Round(ranked[i].score*100) > math.Round(ranked[j].score*100)
+	})
+	return ranked
+}
+
+func describeScore(sq scoredQuote) string {
+	minimum := T("score.meets")
+	if !sq.meetsMinimum {
+		minimum = T("score.does_not_meet")
+	}
+	return T("score.detail",
+		sq.priceScore*100, sq.productCost, sq.shippingScore*100, sq.shipping, sq.deliveryScore*100, formatDeliveryDays(sq.quote), minimum)
+}
+
+func describeWeights(w scoreWeights) string {
+	return T("score.weights", formatFloat(w.price), formatFloat(w.shipping), formatFloat(w.delivery), formatFloat(w.minOrder))
+}
+
+func generateScoreReport(groupID uint, category string, start, end time.Time, weights scoreWeights, opts reportOptions) string {
+	prescriptions := loadReportPrescriptions(groupID, category, start, end)
+
+	var sb strings.Builder
+	sb.WriteString(T("score.report_title", formatPeriod(start, end), describeWeights(weights)))
+
+	var currentCategory string
+	for _, pres := range prescriptions {
+		writeCategoryHeader(&sb, &currentCategory, pres)
+		if pres.Product.ID == 0 {
+			sb.WriteString(T("report.product_not_found", pres.ProductID))
+			continue
+		}
+		requiredQty, err := requiredStandardQuantity(pres)
+		if err != nil {
+			sb.WriteString(T("report.unit_mismatch", pres.RequiredUnit, pres.Product.StandardUnit, pres.Product.Name))
+			continue
+		}
+
+		quotes, err := quoteRepo.FindByDate(pres.ProductID, start, end)
+		if err != nil {
+			sb.WriteString(T("report.quotes_load_error", pres.Product.Name, err))
+			continue
+		}
+		quotes, _ = splitExpiredQuotes(quotes, end)
+		quotes, _ = filterByDelivery(quotes, opts.deliveryLimit)
+		costs, _ := rankQuotes(quotes, requiredQty, opts.tolerance)
+		ranked := rankByScore(costs, requiredQty, weights)
+		if len(ranked) == 0 {
+			sb.WriteString(T("score.no_valid_quotes", pres.Product.Name, formatPeriod(start, end)))
+			continue
+		}
+
+		sb.WriteString(T("report.for_product", pres.Product.Name, formatRequiredQuantity(pres, requiredQty)))
+		for idx, sq := range ranked {
+			sb.WriteString(T("score.ranked_line", idx+1, sq.quote.Store.Name, sq.score, formatCost(sq.quoteCost)))
+			sb.WriteString(describeScore(sq))
+		}
+		if cheapest := costs[0]; cheapest.quote.ID != ranked[0].quote.ID {
+			sb.WriteString(T("score.cheapest_note", cheapest.quote.Store.Name, formatCost(cheapest)))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func parseWeight(text, name string) (float64, error) {
+	text = strings.TrimSpace(strings.ReplaceAll(text, ",", "."))
+	if text == "" {
+		return 0, nil
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || value < 0 {
+		return 0, errors.New(T("score.invalid_weight", name))
+	}
+	return value, nil
+}
+
+func showScoreWeightsDialog(w fyne.Window, onConfirm func(scoreWeights)) {
+	entries := []*widget.Entry{newEntry(), newEntry(), newEntry(), newEntry()}
+	names := []string{T("score.name_price"), T("score.name_shipping"), T("score.name_delivery"), T("score.name_min_order")}
+	current := []float64{scoreWeightsInUse.price, scoreWeightsInUse.shipping, scoreWeightsInUse.delivery, scoreWeightsInUse.minOrder}
+	for i, e := range entries {
+		e.SetText(formatFloat(current[i]))
+	}
+	resetBtn := newButton(T("score.reset"), func() {
+		defaults := []float64{defaultScoreWeights.price, defaultScoreWeights.shipping, defaultScoreWeights.delivery, defaultScoreWeights.minOrder}
+		for i, e := range entries {
+			e.SetText(formatFloat(defaults[i]))
+		}
+	})
+	items := []*widget.FormItem{
+		widget.NewFormItem(T("score.weight_price"), entries[0]),
+		widget.NewFormItem(T("score.weight_shipping"), entries[1]),
+		widget.NewFormItem(T("score.weight_delivery"), entries[2]),
+		widget.NewFormItem(T("score.weight_min_order"), entries[3]),
+		widget.NewFormItem("", container.NewHBox(resetBtn)),
+	}
+	dialog.ShowForm(T("score.title"), T("score.generate"), T("common.cancel"), items, func(ok bool) {
+		if !ok {
+			return
+		}
+		values := make([]float64, len(entries))
+		for i, e := range entries {
+			value, err := parseWeight(e.Text, names[i])
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			values[i] = value
+		}
+		weights := scoreWeights{price: values[0], shipping: values[1], delivery: values[2], minOrder: values[3]}
+		if weights.total() <= 0 {
+			dialog.ShowError(errors.New(T("score.no_weight")), w)
+			return
+		}
+		scoreWeightsInUse = weights
+		onConfirm(weights)
+	}, w)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func readSeason(startPicker, endPicker *DatePicker) (*time.Time, *time.Time, error) {
+	var start, end *time.Time
+	if t, ok := startPicker.Date(); ok {
+		start = &t
+	}
+	if t, ok := endPicker.Date(); ok {
+		end = &t
+	}
+	if start != nil && end != nil && end.Before(*start) {
+		return nil, nil, errors.New(T("season.invalid"))
+	}
+	return start, end, nil
+}
+
+func setSeason(startPicker, endPicker *DatePicker, pres Prescription) {
+	if pres.SeasonStart != nil {
+		startPicker.SetDate(*pres.SeasonStart)
+	}
+	if pres.SeasonEnd != nil {
+		endPicker.SetDate(*pres.SeasonEnd)
+	}
+}
+
+// Receituário sem início ou fim de safra vale sem limite daquele lado.
+func inSeason(start, end time.Time) func(*gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("(prescriptions.season_start IS NULL OR prescriptions.season_start <= ?) AND (prescriptions.season_end IS NULL OR prescriptions.season_end >= ?)", end, start)
+	}
+}
+
+func formatSeason(pres Prescription) string {
+	switch {
+	case pres.SeasonStart != nil && pres.SeasonEnd != nil:
+		return fmt.Sprintf("safra %s a %s", pres.SeasonStart.Format("2006-01-02"), pres.SeasonEnd.Format("2006-01-02"))
+	case pres.SeasonStart != nil:
+		return fmt.Sprintf("safra a partir de %s", pres.SeasonStart.Format("2006-01-02"))
+	case pres.SeasonEnd != nil:
+		return fmt.Sprintf("safra até %s", pres.SeasonEnd.Format("2006-01-02"))
+	}
+	return ""
+}
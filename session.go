@@ -0,0 +1,128 @@
+package main
+
+import (
+	"image/color"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+)
+
+const defaultSessionTimeoutMinutes = 15
+
+type activityCatcher struct {
+	widget.BaseWidget
+	onActivity func()
+}
+
+func newActivityCatcher(onActivity func()) *activityCatcher {
+	c := &activityCatcher{onActivity: onActivity}
+	c.ExtendBaseWidget(c)
+	return c
+}
+
+func (c *activityCatcher) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(canvas.NewRectangle(color.Transparent))
+}
+
+func (c *activityCatcher) MouseIn(*desktop.MouseEvent)    { c.onActivity() }
+func (c *activityCatcher) MouseMoved(*desktop.MouseEvent) { c.onActivity() }
+func (c *activityCatcher) MouseOut()                      {}
+
+type sessionTimer struct {
+	mu      sync.Mutex
+	timer   *time.Timer
+	timeout time.Duration
+}
+
+var activeSession *sessionTimer
+
+func sessionTimeout() time.Duration {
+	return time.Duration(envInt("SESSION_TIMEOUT_MINUTES", defaultSessionTimeoutMinutes)) * time.Minute
+}
+
+func (s *sessionTimer) touch() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.timer != nil {
+		s.timer.Reset(s.timeout)
+	}
+}
+
+func (s *sessionTimer) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+}
+
+func withSessionTimeout(w fyne.Window, content fyne.CanvasObject) fyne.CanvasObject {
+	stopSessionTimeout()
+	timeout := sessionTimeout()
+	if timeout <= 0 {
+		return content
+	}
+	session := &sessionTimer{timeout: timeout}
+	session.timer = time.AfterFunc(timeout, func() {
+		fyne.Do(func() {
+			if activeSession != session || currentUser == nil {
+				return
+			}
+			logout(w)
+			dialog.ShowInformation(T("session.expired_title"), T("session.expired_message"), w)
+		})
+	})
+	activeSession = session
+
+	if dc, ok := w.Canvas().(desktop.Canvas); ok {
+		dc.SetOnKeyDown(func(*fyne.KeyEvent) { session.touch() })
+	}
+	return container.NewStack(newActivityCatcher(session.touch), content)
+}
+
+// Com um campo focado o canvas não recebe as teclas, e botões consomem o
+// clique, então os widgets criados por estes construtores avisam a sessão
+// diretamente.
+func newEntry() *widget.Entry {
+	return trackActivity(widget.NewEntry())
+}
+
+func newPasswordEntry() *widget.Entry {
+	return trackActivity(widget.NewPasswordEntry())
+}
+
+func newMultiLineEntry() *widget.Entry {
+	return trackActivity(widget.NewMultiLineEntry())
+}
+
+func trackActivity(entry *widget.Entry) *widget.Entry {
+	entry.OnCursorChanged = touchSession
+	return entry
+}
+
+func newButton(label string, tapped func()) *widget.Button {
+	return widget.NewButton(label, func() {
+		touchSession()
+		tapped()
+	})
+}
+
+func touchSession() {
+	if activeSession != nil {
+		activeSession.touch()
+	}
+}
+
+func stopSessionTimeout() {
+	if activeSession != nil {
+		activeSession.stop()
+		activeSession = nil
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	shippingPerOrder = "shipping.per_order"
+	shippingPerUnit  = "shipping.per_unit"
+)
+
+var shippingModes = []string{shippingPerOrder, shippingPerUnit}
+
+func parseShippingCost(text string) (float64, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0, nil
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, errors.New(T("shipping.invalid"))
+	}
+	if value < 0 {
+		return 0, errors.New(T("shipping.negative"))
+	}
+	return value, nil
+}
+
+func shippingMode(q Quote) string {
+	if q.ShippingPerUnit {
+		return shippingPerUnit
+	}
+	return shippingPerOrder
+}
+
+func formatShipping(q Quote) string {
+	if q.ShippingCost == 0 {
+		return T("shipping.none")
+	}
+	if q.ShippingPerUnit {
+		return fmt.Sprintf("%s %.2f/%s", q.Currency, q.ShippingCost, q.Product.StandardUnit)
+	}
+	return T("shipping.per_order_value", q.Currency, q.ShippingCost)
+}
+
+func shippingField(entry *widget.Entry, mode *widget.Select) fyne.CanvasObject {
+	entry.SetPlaceHolder(T("shipping.placeholder"))
+	if mode.Selected == "" {
+		mode.SetSelected(T(shippingPerOrder))
+	}
+	return container.NewBorder(nil, nil, nil, mode, entry)
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+)
+
+type tabActions struct {
+	submit         func()
+	newRecord      func()
+	deleteSelected func()
+}
+
+var tabShortcuts = make(map[string]tabActions)
+
+var newRecordShortcut = &desktop.CustomShortcut{KeyName: fyne.KeyN, Modifier: fyne.KeyModifierShortcutDefault}
+
+func registerTabActions(tabKey string, actions tabActions) {
+	if isAuditor() {
+		return
+	}
+	tabShortcuts[tabKey] = actions
+}
+
+func submitOnEnter(submit func(), entries ...*widget.Entry) {
+	for _, e := range entries {
+		e.OnSubmitted = func(string) {
+			touchSession()
+			submit()
+		}
+	}
+}
+
+// Ao tocar numa linha a lista recebe o foco e o canvas deixa de receber as
+// teclas, então a própria lista repassa o Delete para a aba ativa.
+type selectionList struct {
+	widget.List
+}
+
+var deleteFromList func()
+
+func newSelectionList(length func() int, createItem func() fyne.CanvasObject, updateItem func(widget.ListItemID, fyne.CanvasObject)) *selectionList {
+	l := &selectionList{}
+	l.Length = length
+	l.CreateItem = createItem
+	l.UpdateItem = updateItem
+	l.ExtendBaseWidget(l)
+	return l
+}
+
+func newSelectionListWithData(data binding.DataList, createItem func() fyne.CanvasObject, updateItem func(binding.DataItem, fyne.CanvasObject)) *selectionList {
+	l := newSelectionList(data.Length, createItem, func(id widget.ListItemID, co fyne.CanvasObject) {
+		item, err := data.GetItem(id)
+		if err != nil {
+			return
+		}
+		updateItem(item, co)
+	})
+	data.AddListener(binding.NewDataListener(l.Refresh))
+	return l
+}
+
+func (l *selectionList) TypedKey(ev *fyne.KeyEvent) {
+	if ev.Name == fyne.KeyDelete && deleteFromList != nil {
+		deleteFromList()
+		return
+	}
+	l.List.TypedKey(ev)
+}
+
+func activeTabActions(tabs *container.AppTabs, tabKeys map[*container.TabItem]string) (tabActions, bool) {
+	item := tabs.Selected()
+	if item == nil {
+		return tabActions{}, false
+	}
+	actions, ok := tabShortcuts[tabKeys[item]]
+	return actions, ok
+}
+
+func installShortcuts(w fyne.Window, tabs *container.AppTabs, tabKeys map[*container.TabItem]string) {
+	c := w.Canvas()
+	c.AddShortcut(newRecordShortcut, func(fyne.Shortcut) {
+		if actions, ok := activeTabActions(tabs, tabKeys); ok && actions.newRecord != nil {
+			actions.newRecord()
+		}
+	})
+	deleteFromList = func() {
+		if actions, ok := activeTabActions(tabs, tabKeys); ok && actions.deleteSelected != nil {
+			actions.deleteSelected()
+		}
+	}
+	// Só é chamado sem nenhum widget focado; campos e listas tratam as
+	// próprias teclas.
+	c.SetOnTypedKey(func(ev *fyne.KeyEvent) {
+		actions, ok := activeTabActions(tabs, tabKeys)
+		if !ok {
+			return
+		}
+		switch ev.Name {
+		case fyne.KeyDelete:
+			deleteFromList()
+		case fyne.KeyReturn, fyne.KeyEnter:
+			if actions.submit != nil {
+				actions.submit()
+			}
+		}
+	})
+}
+
+func removeShortcuts(w fyne.Window) {
+	w.Canvas().RemoveShortcut(newRecordShortcut)
+	w.Canvas().SetOnTypedKey(nil)
+	deleteFromList = nil
+	tabShortcuts = make(map[string]tabActions)
+	recordFocusers = make(map[string]func(id uint))
+	tabRefreshers = nil
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+func simulateCosts(quotes []Quote, qty float64) []quoteCost {
+	var costs []quoteCost
+	var storeIDs []uint
+	for _, q := range quotes {
+		qc, err := quoteTotalCost(q, qty)
+		if err != nil {
+			continue
+		}
+		costs = append(costs, qc)
+		storeIDs = append(storeIDs, q.StoreID)
+	}
+	sortByCost(costs, storesWithContacts(storeIDs))
+	return costs
+}
+
+func parseSimulatedQuantity(text string) (float64, bool) {
+	value, err := strconv.ParseFloat(strings.TrimSpace(strings.ReplaceAll(text, ",", ".")), 64)
+	return value, err == nil && value > 0
+}
+
+func simulatorTab(w fyne.Window) fyne.CanvasObject {
+	productSelect := widget.NewSelect(productOptions, nil)
+	datePicker := NewDatePicker()
+	datePicker.SetDate(time.Now())
+	qtyEntry := newEntry()
+	qtyEntry.SetPlaceHolder(T("simulator.quantity_placeholder"))
+	summaryLabel := widget.NewLabel(T("simulator.hint"))
+
+	var product Product
+	var quotes []Quote
+	var costs []quoteCost
+	headers := []string{"#", T("compare.store"), T("simulator.total_cost"), T("compare.difference")}
+
+	table := widget.NewTable(
+		func() (int, int) {
+			return len(costs) + 1, len(headers)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("template template")
+		},
+		func(id widget.TableCellID, co fyne.CanvasObject) {
+			label := co.(*widget.Label)
+			label.Importance = widget.MediumImportance
+			label.TextStyle = fyne.TextStyle{}
+			if id.Row == 0 {
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				label.SetText(headers[id.Col])
+				return
+			}
+			qc := costs[id.Row-1]
+			if id.Row == 1 {
+				label.Importance = widget.SuccessImportance
+			}
+			switch id.Col {
+			case 0:
+				label.SetText(strconv.Itoa(id.Row))
+			case 1:
+				label.SetText(qc.quote.Store.Name)
+			case 2:
+				label.SetText(formatCost(qc))
+			case 3:
+				if id.Row == 1 {
+					label.SetText(T("simulator.cheapest"))
+				} else {
+					label.SetText(fmt.Sprintf("+R$ %.2f", qc.cost-costs[0].cost))
+				}
+			}
+		},
+	)
+	table.SetColumnWidth(0, 40)
+	table.SetColumnWidth(1, 220)
+	table.SetColumnWidth(2, 360)
+	table.SetColumnWidth(3, 160)
+
+	simulate := func() {
+		costs = nil
+		qty, ok := parseSimulatedQuantity(qtyEntry.Text)
+		switch {
+		case product.ID == 0:
+		case len(quotes) == 0:
+			summaryLabel.SetText(T("simulator.no_quotes", product.Name))
+		case !ok:
+			summaryLabel.SetText(T("simulator.invalid_quantity"))
+		default:
+			costs = simulateCosts(quotes, qty)
+			summaryLabel.SetText(T("simulator.summary", formatFloat(qty), product.StandardUnit, product.Name, len(costs)))
+		}
+		table.Refresh()
+	}
+
+	selection := func() (uint, time.Time, bool) {
+		productID, ok := productMap[productSelect.Selected]
+		date, okDate := datePicker.Date()
+		return productID, date, ok && okDate
+	}
+	fetch := func(productID uint, date time.Time) (Product, []Quote, error) {
+		var p Product
+		if err := db.First(&p, productID).Error; err != nil {
+			return p, nil, err
+		}
+		loaded, err := quoteRepo.FindByDate(productID, date, date)
+		if err != nil {
+			return p, nil, err
+		}
+		valid, _ := splitExpiredQuotes(loaded, date)
+		return p, valid, nil
+	}
+	show := func(p Product, valid []Quote) {
+		product, quotes = p, valid
+		qtyEntry.SetPlaceHolder(T("simulator.quantity_in", product.StandardUnit))
+		simulate()
+	}
+	load := func() {
+		productID, date, ok := selection()
+		if !ok {
+			return
+		}
+		p, valid, err := fetch(productID, date)
+		if err != nil {
+			showDBError(err, w)
+			return
+		}
+		show(p, valid)
+	}
+
+	productSelect.OnChanged = func(string) { load() }
+	datePicker.OnChanged = func(time.Time) { load() }
+	qtyEntry.OnChanged = func(string) { simulate() }
+
+	refreshBtn := newButton(T("history.refresh_products"), func() {
+		productOptions, productMap = loadProductOptions()
+		productSelect.Options = productOptions
+		productSelect.Refresh()
+	})
+	registerRefresh(func() (func(), func()) {
+		productID, date, ok := selection()
+		var p Product
+		var valid []Quote
+		var err error
+		return func() {
+				if ok {
+					p, valid, err = fetch(productID, date)
+				}
+			}, func() {
+				productSelect.Options = productOptions
+				productSelect.Refresh()
+				if !ok {
+					return
+				}
+				if err != nil {
+					showDBError(err, w)
+					return
+				}
+				show(p, valid)
+			}
+	})
+
+	top := container.NewVBox(
+		widget.NewForm(
+			widget.NewFormItem(T("compare.product"), productSelect),
+			widget.NewFormItem(T("compare.date"), datePicker),
+			widget.NewFormItem(T("simulator.quantity"), qtyEntry),
+		),
+		refreshBtn,
+		summaryLabel,
+	)
+	return container.NewBorder(top, nil, nil, nil, table)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"gorm.io/gorm/clause"
+)
+
+const (
+	sortInsertion     = "sort.insertion"
+	sortNameAsc       = "sort.name_asc"
+	sortNameDesc      = "sort.name_desc"
+	sortDateDesc      = "sort.date_desc"
+	sortDateAsc       = "sort.date_asc"
+	sortPriceAsc      = "sort.price_asc"
+	sortPriceDesc     = "sort.price_desc"
+	sortUnitPriceAsc  = "sort.unit_price_asc"
+	sortUnitPriceDesc = "sort.unit_price_desc"
+	sortProductAsc    = "sort.product_asc"
+	sortProductDesc   = "sort.product_desc"
+)
+
+var nameSortOptions = []string{sortInsertion, sortNameAsc, sortNameDesc}
+
+var quoteSortOptions = []string{sortInsertion, sortDateDesc, sortDateAsc, sortPriceAsc, sortPriceDesc, sortUnitPriceAsc, sortUnitPriceDesc}
+
+var prescriptionSortOptions = []string{sortInsertion, sortProductAsc, sortProductDesc}
+
+// A ordenação por nome é feita em memória para ignorar acentos e caixa, o que
+// a collation do banco não garante.
+func sortByName[T any](items []T, name func(T) string, option string) {
+	if option != sortNameAsc && option != sortNameDesc {
+		return
+	}
+	desc := option == sortNameDesc
+	sort.SliceStable(items, func(i, j int) bool {
+		if desc {
+			return lessFolded(name(items[j]), name(items[i]))
+		}
+		return lessFolded(name(items[i]), name(items[j]))
+	})
+}
+
+func quoteOrderClause(option string) string {
+	switch option {
+	case sortDateDesc:
+		return "quotes.date desc, quotes.id"
+	case sortDateAsc:
+		return "quotes.date asc, quotes.id"
+	case sortPriceAsc:
+		return "quotes.price asc, quotes.id"
+	case sortPriceDesc:
+		return "quotes.price desc, quotes.id"
+	}
+	return "quotes.id"
+}
+
+func isUnitPriceSort(option string) bool {
+	return option == sortUnitPriceAsc || option == sortUnitPriceDesc
+}
+
+// O preço por unidade padrão é calculado no banco, como em
+// pricePerStandardUnit, para paginar sem carregar todas as cotações. Moeda sem
+// taxa de câmbio ou embalagem zerada resulta em NULL e vai para o fim da lista.
+func unitPriceOrder(desc bool) clause.OrderBy {
+	var currencies []string
+	db.Model(&Quote{}).Distinct().Pluck("currency", &currencies)
+	rate := "CASE WHEN quotes.currency = '' OR quotes.currency = ? THEN 1"
+	vars := []any{defaultCurrency}
+	for _, currency := range currencies {
+		if currency == "" || currency == defaultCurrency {
+			continue
+		}
+		if r, err := exchangeRate(currency); err == nil {
+			rate += " WHEN quotes.currency = ? THEN " + strconv.FormatFloat(r, 'f', -1, 64)
+			vars = append(vars, currency)
+		}
+	}
+	expr := "quotes.price * (" + rate + " END) / NULLIF(quotes.packaging_size * quotes.conversion_factor, 0)"
+	direction := "ASC"
+	if desc {
+		direction = "DESC"
+	}
+	return clause.OrderBy{Expression: clause.Expr{
+		SQL:                fmt.Sprintf("(%s) IS NULL, %s %s, quotes.id", expr, expr, direction),
+		Vars:               append(vars, vars...),
+		WithoutParentheses: true,
+	}}
+}
+
+func prescriptionOrderClause(option string) string {
+	switch option {
+	case sortProductAsc:
+		return "products.name asc, prescriptions.id"
+	case sortProductDesc:
+		return "products.name desc, prescriptions.id"
+	}
+	return "prescriptions.id"
+}
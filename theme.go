@@ -0,0 +1,72 @@
+package main
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	themeSystem       = "theme.system"
+	themeLight        = "theme.light"
+	themeDark         = "theme.dark"
+	themeHighContrast = "theme.high_contrast"
+	prefTheme         = "theme"
+)
+
+var themeOptions = []string{themeSystem, themeLight, themeDark, themeHighContrast}
+
+type appTheme struct {
+	fyne.Theme
+	variant      fyne.ThemeVariant
+	highContrast bool
+}
+
+func (t *appTheme) Color(name fyne.ThemeColorName, _ fyne.ThemeVariant) color.Color {
+	if t.highContrast {
+		switch name {
+		case theme.ColorNameBackground, theme.ColorNameInputBackground, theme.ColorNameMenuBackground, theme.ColorNameOverlayBackground, theme.ColorNameHeaderBackground:
+			return color.Black
+		case theme.ColorNameForeground, theme.ColorNameInputBorder, theme.ColorNameSeparator:
+			return color.White
+		case theme.ColorNamePrimary, theme.ColorNameFocus, theme.ColorNameHyperlink:
+			return color.NRGBA{R: 0xff, G: 0xd7, B: 0x00, A: 0xff}
+		case theme.ColorNameButton:
+			return color.NRGBA{R: 0x33, G: 0x33, B: 0x33, A: 0xff}
+		case theme.ColorNamePlaceHolder, theme.ColorNameDisabled:
+			return color.NRGBA{R: 0xc0, G: 0xc0, B: 0xc0, A: 0xff}
+		}
+	}
+	return t.Theme.Color(name, t.variant)
+}
+
+func applyTheme(a fyne.App, name string) {
+	switch name {
+	case themeLight:
+		a.Settings().SetTheme(&appTheme{Theme: theme.DefaultTheme(), variant: theme.VariantLight})
+	case themeDark:
+		a.Settings().SetTheme(&appTheme{Theme: theme.DefaultTheme(), variant: theme.VariantDark})
+	case themeHighContrast:
+		a.Settings().SetTheme(&appTheme{Theme: theme.DefaultTheme(), variant: theme.VariantDark, highContrast: true})
+	default:
+		name = themeSystem
+		a.Settings().SetTheme(theme.DefaultTheme())
+	}
+	a.Preferences().SetString(prefTheme, name)
+}
+
+func loadSavedTheme(a fyne.App) {
+	applyTheme(a, a.Preferences().StringWithFallback(prefTheme, themeSystem))
+}
+
+func themeSelector() *widget.Select {
+	a := fyne.CurrentApp()
+	sel := widget.NewSelect(translatedOptions(themeOptions), nil)
+	sel.SetSelected(T(a.Preferences().StringWithFallback(prefTheme, themeSystem)))
+	sel.OnChanged = func(label string) {
+		applyTheme(a, optionKey(themeOptions, label))
+	}
+	return sel
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+	"gorm.io/gorm"
+)
+
+type PriceTier struct {
+	gorm.Model
+	QuoteID     uint    `gorm:"not null;index"`
+	MinQuantity float64 `gorm:"not null"`
+	Price       float64 `gorm:"not null"`
+}
+
+func applicableTier(q Quote, requiredQty float64) (PriceTier, bool) {
+	var best PriceTier
+	found := false
+	for _, t := range q.Tiers {
+		if t.MinQuantity <= requiredQty && (!found || t.MinQuantity > best.MinQuantity) {
+			best = t
+			found = true
+		}
+	}
+	return best, found
+}
+
+func formatTier(q Quote, t PriceTier) string {
+	return T("tiers.report_range", t.MinQuantity, q.Product.StandardUnit, q.Currency, t.Price)
+}
+
+func showPriceTiers(w fyne.Window, quote Quote, onChanged func()) {
+	minEntry := newEntry()
+	minEntry.SetPlaceHolder(T("tiers.min_placeholder", quote.Product.StandardUnit))
+	priceEntry := newEntry()
+	priceEntry.SetPlaceHolder(T("tiers.price_placeholder", quote.Currency))
+	info := widget.NewLabel(T("tiers.info", quote.Currency, quote.Price))
+	info.Wrapping = fyne.TextWrapWord
+
+	showChildRecords(w, childRecords[PriceTier]{
+		title: T("tiers.title", quote.ID),
+		intro: info,
+		form: widget.NewForm(
+			widget.NewFormItem(T("tiers.min_quantity"), minEntry),
+			widget.NewFormItem(T("tiers.price"), priceEntry),
+		),
+		addLabel:      T("tiers.add"),
+		removeLabel:   T("tiers.remove"),
+		listLabel:     T("tiers.list"),
+		noSelection:   T("tiers.no_selection"),
+		confirmRemove: T("tiers.confirm_remove"),
+		auditEntity:   "Faixa de Preço",
+		load: func() []PriceTier {
+			var tiers []PriceTier
+			db.Where("quote_id = ?", quote.ID).Order("min_quantity").Find(&tiers)
+			return tiers
+		},
+		format: func(t PriceTier) string { return formatTier(quote, t) },
+		id:     func(t PriceTier) uint { return t.ID },
+		auditDetail: func(t PriceTier) string {
+			return fmt.Sprintf("Cotação %d - %s", quote.ID, formatTier(quote, t))
+		},
+		build: func(tiers []PriceTier) (PriceTier, error) {
+			minQty, err := strconv.ParseFloat(strings.TrimSpace(minEntry.Text), 64)
+			if err != nil || minQty <= 0 {
+				return PriceTier{}, errors.New(T("tiers.invalid_min"))
+			}
+			price, err := strconv.ParseFloat(strings.TrimSpace(priceEntry.Text), 64)
+			if err != nil || price <= 0 {
+				return PriceTier{}, errors.New(T("tiers.invalid_price"))
+			}
+			for _, t := range tiers {
+				if t.MinQuantity == minQty {
+					return PriceTier{}, errors.New(T("tiers.duplicate", minQty))
+				}
+			}
+			return PriceTier{QuoteID: quote.ID, MinQuantity: minQty, Price: price}, nil
+		},
+		clearForm: func() {
+			minEntry.SetText("")
+			priceEntry.SetText("")
+		},
+	}, onChanged)
+}
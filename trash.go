@@ -0,0 +1,275 @@
+package main
+
+import (
+	"errors"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"gorm.io/gorm"
+)
+
+type trashItem struct {
+	id    uint
+	label string
+}
+
+type trashEntity struct {
+	name       string // chave de tradução
+	entity     string
+	model      func() interface{}
+	load       func() []trashItem
+	canRestore func(id uint) error
+	canPurge   func(id uint) error
+	purgeWith  func(tx *gorm.DB, id uint) error
+}
+
+func deletedAt(m gorm.Model) string {
+	return m.DeletedAt.Time.Local().Format("2006-01-02 15:04")
+}
+
+func requireActive(model interface{}, id uint, what string) error {
+	if err := db.First(model, id).Error; err != nil {
+		return errors.New(T("trash.inactive_reference", T(what), id))
+	}
+	return nil
+}
+
+func requireNoReferences(model interface{}, column string, id uint, what string) error {
+	var count int64
+	db.Unscoped().Model(model).Where(column+" = ?", id).Count(&count)
+	if count > 0 {
+		return errors.New(T("trash.has_references", count, T(what)))
+	}
+	return nil
+}
+
+var trashEntities = []trashEntity{
+	{
+		name:   "tab.products",
+		entity: "Produto",
+		model:  func() interface{} { return &Product{} },
+		load: func() []trashItem {
+			var products []Product
+			db.Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at desc").Find(&products)
+			var items []trashItem
+			for _, p := range products {
+				items = append(items, trashItem{p.ID, T("trash.product_item", p.ID, p.Name, p.StandardUnit, deletedAt(p.Model))})
+			}
+			return items
+		},
+		canRestore: func(id uint) error { return nil },
+		canPurge: func(id uint) error {
+			if err := requireNoReferences(&Quote{}, "product_id", id, "trash.quotes"); err != nil {
+				return err
+			}
+			return requireNoReferences(&Prescription{}, "product_id", id, "trash.prescriptions")
+		},
+	},
+	{
+		name:   "tab.stores",
+		entity: "Loja",
+		model:  func() interface{} { return &Store{} },
+		load: func() []trashItem {
+			var stores []Store
+			db.Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at desc").Find(&stores)
+			var items []trashItem
+			for _, s := range stores {
+				items = append(items, trashItem{s.ID, T("trash.store_item", s.ID, s.Name, s.Endereco, deletedAt(s.Model))})
+			}
+			return items
+		},
+		canRestore: func(id uint) error { return nil },
+		canPurge: func(id uint) error {
+			return requireNoReferences(&Quote{}, "store_id", id, "trash.quotes")
+		},
+		purgeWith: func(tx *gorm.DB, id uint) error {
+			return tx.Unscoped().Where("store_id = ?", id).Delete(&StoreContact{}).Error
+		},
+	},
+	{
+		name:   "tab.quotes",
+		entity: "Cotação",
+		model:  func() interface{} { return &Quote{} },
+		load: func() []trashItem {
+			var quotes []Quote
+			db.Unscoped().Preload("Product", func(tx *gorm.DB) *gorm.DB { return tx.Unscoped() }).
+				Preload("Store", func(tx *gorm.DB) *gorm.DB { return tx.Unscoped() }).
+				Where("deleted_at IS NOT NULL").Order("deleted_at desc").Find(&quotes)
+			var items []trashItem
+			for _, q := range quotes {
+				items = append(items, trashItem{q.ID, T("trash.quote_item", q.ID, q.Product.Name, q.Store.Name, formatQuotePrice(q), q.Date.Format("2006-01-02"), deletedAt(q.Model))})
+			}
+			return items
+		},
+		canRestore: func(id uint) error {
+			var quote Quote
+			db.Unscoped().First(&quote, id)
+			if err := requireActive(&Product{}, quote.ProductID, "trash.the_product"); err != nil {
+				return err
+			}
+			return requireActive(&Store{}, quote.StoreID, "trash.the_store")
+		},
+		canPurge: func(id uint) error { return nil },
+		purgeWith: func(tx *gorm.DB, id uint) error {
+			return tx.Unscoped().Where("quote_id = ?", id).Delete(&PriceTier{}).Error
+		},
+	},
+	{
+		name:   "tab.prescriptions",
+		entity: "Receituário",
+		model:  func() interface{} { return &Prescription{} },
+		load: func() []trashItem {
+			var pres []Prescription
+			db.Unscoped().Preload("Product", func(tx *gorm.DB) *gorm.DB { return tx.Unscoped() }).
+				Preload("Group", func(tx *gorm.DB) *gorm.DB { return tx.Unscoped() }).
+				Where("deleted_at IS NOT NULL").Order("deleted_at desc").Find(&pres)
+			var items []trashItem
+			for _, p := range pres {
+				items = append(items, trashItem{p.ID, T("trash.prescription_item", p.ID, p.Group.Name, p.Product.Name, p.RequiredQuantity, p.RequiredUnit, deletedAt(p.Model))})
+			}
+			return items
+		},
+		canRestore: func(id uint) error {
+			var pres Prescription
+			db.Unscoped().First(&pres, id)
+			if err := requireActive(&Product{}, pres.ProductID, "trash.the_product"); err != nil {
+				return err
+			}
+			return requireActive(&PrescriptionGroup{}, pres.GroupID, "trash.the_group")
+		},
+		canPurge: func(id uint) error { return nil },
+	},
+	{
+		name:   "tab.users",
+		entity: "Usuário",
+		model:  func() interface{} { return &User{} },
+		load: func() []trashItem {
+			var users []User
+			db.Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at desc").Find(&users)
+			var items []trashItem
+			for _, u := range users {
+				items = append(items, trashItem{u.ID, T("trash.user_item", u.ID, u.Username, u.FullName, deletedAt(u.Model))})
+			}
+			return items
+		},
+		canRestore: func(id uint) error { return nil },
+		canPurge:   func(id uint) error { return nil },
+	},
+}
+
+func trashTab(w fyne.Window) fyne.CanvasObject {
+	var names []string
+	for _, e := range trashEntities {
+		names = append(names, T(e.name))
+	}
+
+	var current trashEntity
+	var items []trashItem
+	var selectedID uint
+	listData := binding.NewStringList()
+	list := widget.NewListWithData(listData,
+		func() fyne.CanvasObject {
+			return widget.NewLabel("template")
+		},
+		func(di binding.DataItem, co fyne.CanvasObject) {
+			co.(*widget.Label).Bind(di.(binding.String))
+		},
+	)
+	list.OnSelected = func(id widget.ListItemID) {
+		if id < len(items) {
+			selectedID = items[id].id
+		}
+	}
+
+	refresh := func() {
+		list.UnselectAll()
+		selectedID = 0
+		items = nil
+		if current.load != nil {
+			items = current.load()
+		}
+		var strs []string
+		for _, item := range items {
+			strs = append(strs, item.label)
+		}
+		listData.Set(strs)
+	}
+
+	entitySelect := widget.NewSelect(names, func(name string) {
+		for _, e := range trashEntities {
+			if T(e.name) == name {
+				current = e
+			}
+		}
+		refresh()
+	})
+
+	restoreBtn := newButton(T("trash.restore"), func() {
+		if selectedID == 0 {
+			dialog.ShowError(errors.New(T("trash.select_to_restore")), w)
+			return
+		}
+		if err := current.canRestore(selectedID); err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		id := selectedID
+		if err := db.Unscoped().Model(current.model()).Where("id = ?", id).Update("deleted_at", nil).Error; err != nil {
+			showDBError(err, w)
+			return
+		}
+		recordAudit(auditRestore, current.entity, id, "")
+		invalidateProductCache()
+		invalidateStoreCache()
+		dialog.ShowInformation(T("common.success"), T("trash.restored"), w)
+		refresh()
+	})
+
+	purgeBtn := newButton(T("trash.purge"), func() {
+		if selectedID == 0 {
+			dialog.ShowError(errors.New(T("trash.select_to_purge")), w)
+			return
+		}
+		if err := current.canPurge(selectedID); err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		id := selectedID
+		dialog.ShowConfirm(T("common.confirmation"), T("trash.confirm_purge"), func(confirm bool) {
+			if !confirm {
+				return
+			}
+			err := db.Transaction(func(tx *gorm.DB) error {
+				if current.purgeWith != nil {
+					if err := current.purgeWith(tx, id); err != nil {
+						return err
+					}
+				}
+				return tx.Unscoped().Delete(current.model(), id).Error
+			})
+			if err != nil {
+				showDBError(err, w)
+				return
+			}
+			recordAudit(auditPurge, current.entity, id, "")
+			invalidateProductCache()
+			invalidateStoreCache()
+			dialog.ShowInformation(T("common.success"), T("trash.purged"), w)
+			refresh()
+		}, w)
+	})
+
+	refreshBtn := newButton(T("common.refresh"), refresh)
+	entitySelect.SetSelected(names[0])
+	writeActions(restoreBtn, purgeBtn)
+
+	top := container.NewVBox(
+		widget.NewForm(widget.NewFormItem(T("trash.type"), entitySelect)),
+		container.NewHBox(restoreBtn, purgeBtn, refreshBtn),
+		widget.NewLabel(T("trash.items")),
+	)
+	return container.NewBorder(top, nil, nil, nil, list)
+}
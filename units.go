@@ -0,0 +1,229 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+type unitInfo struct {
+	dimension string
+	toBase    float64
+}
+
+var knownUnits = map[string]unitInfo{
+	"MG":    {"massa", 0.000001},
+	"G":     {"massa", 0.001},
+	"GR":    {"massa", 0.001},
+	"KG":    {"massa", 1},
+	"KILO":  {"massa", 1},
+	"T":     {"massa", 1000},
+	"TON":   {"massa", 1000},
+	"ML":    {"volume", 0.001},
+	"L":     {"volume", 1},
+	"LT":    {"volume", 1},
+	"LITRO": {"volume", 1},
+	"M3":    {"volume", 1000},
+	"UN":    {"unidade", 1},
+	"UND":   {"unidade", 1},
+	"DZ":    {"unidade", 12},
+	"DUZIA": {"unidade", 12},
+}
+
+var standardUnits = []string{"DZ", "G", "KG", "LT", "M3", "MG", "ML", "T", "UN"}
+
+const maxUnitLength = 10
+
+func normalizeUnit(s string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(s), ""))
+}
+
+func sameUnit(a, b string) bool {
+	return normalizeUnit(a) == normalizeUnit(b)
+}
+
+func lookupUnit(unit string) (unitInfo, bool) {
+	info, ok := knownUnits[normalizeUnit(unit)]
+	return info, ok
+}
+
+func convertToStandard(value float64, from, to string) (float64, error) {
+	fromInfo, ok := lookupUnit(from)
+	if !ok {
+		return 0, errors.New(T("units.unknown", from))
+	}
+	toInfo, ok := lookupUnit(to)
+	if !ok {
+		return 0, errors.New(T("units.unknown", to))
+	}
+	if fromInfo.dimension != toInfo.dimension {
+		return 0, errors.New(T("units.incompatible", from, fromInfo.dimension, to, toInfo.dimension))
+	}
+	return value * fromInfo.toBase / toInfo.toBase, nil
+}
+
+func convertWithDensity(value float64, from, to string, density float64) (float64, error) {
+	fromInfo, fromOK := lookupUnit(from)
+	toInfo, toOK := lookupUnit(to)
+	if !fromOK || !toOK || density <= 0 {
+		return convertToStandard(value, from, to)
+	}
+	base := value * fromInfo.toBase
+	switch {
+	case fromInfo.dimension == "volume" && toInfo.dimension == "massa":
+		base *= density
+	case fromInfo.dimension == "massa" && toInfo.dimension == "volume":
+		base /= density
+	default:
+		return convertToStandard(value, from, to)
+	}
+	return base / toInfo.toBase, nil
+}
+
+func convertForProduct(value float64, from string, product Product) (float64, error) {
+	return convertWithDensity(value, from, product.StandardUnit, product.Density)
+}
+
+func parseDensity(text string) (float64, error) {
+	text = strings.TrimSpace(strings.ReplaceAll(text, ",", "."))
+	if text == "" {
+		return 0, nil
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || value <= 0 {
+		return 0, errors.New(T("product.invalid_density"))
+	}
+	return value, nil
+}
+
+func formatDensity(p Product) string {
+	if p.Density <= 0 {
+		return ""
+	}
+	return formatFloat(p.Density)
+}
+
+func autoConversionFactor(productID uint, packagingUnit string) (float64, bool) {
+	var product Product
+	if err := db.First(&product, productID).Error; err != nil {
+		return 0, false
+	}
+	factor, err := convertForProduct(1, packagingUnit, product)
+	if err != nil {
+		return 0, false
+	}
+	return factor, true
+}
+
+func packagingUnitWarning(productID uint, packagingUnit string, factor float64) string {
+	var product Product
+	if err := db.First(&product, productID).Error; err != nil || sameUnit(packagingUnit, product.StandardUnit) {
+		return ""
+	}
+	_, err := convertForProduct(1, packagingUnit, product)
+	if err == nil {
+		return ""
+	}
+	return T("units.packaging_warning",
+		packagingUnit, product.StandardUnit, product.Name, err, formatFloat(factor))
+}
+
+func requiredStandardQuantity(pres Prescription) (float64, error) {
+	if sameUnit(pres.RequiredUnit, pres.Product.StandardUnit) {
+		return pres.RequiredQuantity, nil
+	}
+	return convertForProduct(pres.RequiredQuantity, pres.RequiredUnit, pres.Product)
+}
+
+func formatRequiredQuantity(pres Prescription, requiredQty float64) string {
+	if sameUnit(pres.RequiredUnit, pres.Product.StandardUnit) {
+		return fmt.Sprintf("%.2f %s", pres.RequiredQuantity, pres.RequiredUnit)
+	}
+	return fmt.Sprintf("%.2f %s = %.4g %s", pres.RequiredQuantity, pres.RequiredUnit, requiredQty, pres.Product.StandardUnit)
+}
+
+func canonicalUnit(unit string) (string, bool) {
+	info, ok := lookupUnit(unit)
+	if !ok {
+		return "", false
+	}
+	for _, std := range standardUnits {
+		if knownUnits[std] == info {
+			return std, true
+		}
+	}
+	return "", false
+}
+
+func loadUnitOptions() []string {
+	var used []string
+	db.Model(&Product{}).Distinct().Pluck("standard_unit", &used)
+	options := append([]string{}, standardUnits...)
+	seen := make(map[string]bool)
+	for _, u := range options {
+		seen[u] = true
+	}
+	for _, u := range used {
+		u = normalizeUnit(u)
+		if u != "" && !seen[u] {
+			seen[u] = true
+			options = append(options, u)
+		}
+	}
+	sort.Strings(options)
+	return options
+}
+
+func unitSelectField(w fyne.Window, sel *widget.Select) fyne.CanvasObject {
+	addBtn := newButton(T("units.new"), func() {
+		entry := newEntry()
+		entry.SetPlaceHolder(T("units.placeholder"))
+		dialog.ShowForm(T("units.new"), T("units.add"), T("common.cancel"), []*widget.FormItem{
+			widget.NewFormItem(T("units.symbol"), entry),
+		}, func(ok bool) {
+			if !ok {
+				return
+			}
+			unit := normalizeUnit(entry.Text)
+			if unit == "" || len(unit) > maxUnitLength {
+				dialog.ShowError(errors.New(T("units.invalid_symbol", maxUnitLength)), w)
+				return
+			}
+			if std, ok := canonicalUnit(unit); ok && std != unit {
+				dialog.ShowError(errors.New(T("units.use_canonical", unit, std)), w)
+				sel.SetSelected(std)
+				return
+			}
+			for _, opt := range sel.Options {
+				if opt == unit {
+					sel.SetSelected(unit)
+					return
+				}
+			}
+			add := func() {
+				sel.Options = append(sel.Options, unit)
+				sort.Strings(sel.Options)
+				sel.SetSelected(unit)
+			}
+			if _, known := lookupUnit(unit); known {
+				add()
+				return
+			}
+			dialog.ShowConfirm(T("units.no_conversion_title"),
+				T("units.no_conversion", unit),
+				func(confirm bool) {
+					if confirm {
+						add()
+					}
+				}, w)
+		}, w)
+	})
+	return container.NewBorder(nil, nil, nil, addBtn, sel)
+}
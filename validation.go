@@ -0,0 +1,225 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	maxNameLength    = 100
+	maxAddressLength = 255
+)
+
+const (
+	defaultQuoteMaxFutureDays = 7
+	defaultQuoteOldDays       = 365
+	defaultQuoteMinDate       = "2000-01-01"
+)
+
+func envInt(key string, fallback int) int {
+	value, err := strconv.Atoi(strings.TrimSpace(os.Getenv(key)))
+	if err != nil || value < 0 {
+		return fallback
+	}
+	return value
+}
+
+func quoteMinDate() time.Time {
+	value := strings.TrimSpace(os.Getenv("QUOTE_MIN_DATE"))
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t
+	}
+	t, _ := time.Parse("2006-01-02", defaultQuoteMinDate)
+	return t
+}
+
+func today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func validateQuoteDate(t time.Time) error {
+	maxDays := envInt("QUOTE_MAX_FUTURE_DAYS", defaultQuoteMaxFutureDays)
+	if limit := today().AddDate(0, 0, maxDays); t.After(limit) {
+		return errors.New(T("validation.date_too_late", limit.Format("2006-01-02"), maxDays))
+	}
+	if minDate := quoteMinDate(); t.Before(minDate) {
+		return errors.New(T("validation.date_too_early", minDate.Format("2006-01-02")))
+	}
+	return nil
+}
+
+func quoteDateWarning(t time.Time) string {
+	oldDays := envInt("QUOTE_OLD_DAYS", defaultQuoteOldDays)
+	if t.Before(today().AddDate(0, 0, -oldDays)) {
+		return T("validation.old_date", t.Format("2006-01-02"), oldDays)
+	}
+	return ""
+}
+
+func onlyDigits(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func validateCNPJ(cnpj string) (string, error) {
+	digits := onlyDigits(cnpj)
+	if len(digits) != 14 {
+		return "", errors.New(T("validation.cnpj_length"))
+	}
+	if strings.Count(digits, digits[:1]) == 14 {
+		return "", errors.New(T("validation.cnpj_invalid"))
+	}
+
+	weights1 := []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	weights2 := []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	checkDigit := func(weights []int) byte {
+		sum := 0
+		for i, w := range weights {
+			sum += int(digits[i]-'0') * w
+		}
+		rest := sum % 11
+		if rest < 2 {
+			return '0'
+		}
+		return byte('0' + 11 - rest)
+	}
+	if digits[12] != checkDigit(weights1) || digits[13] != checkDigit(weights2) {
+		return "", errors.New(T("validation.cnpj_check_digits"))
+	}
+	return digits, nil
+}
+
+func formatCNPJ(digits string) string {
+	if len(digits) != 14 {
+		return digits
+	}
+	return fmt.Sprintf("%s.%s.%s/%s-%s", digits[0:2], digits[2:5], digits[5:8], digits[8:12], digits[12:14])
+}
+
+func parseOptionalCNPJ(text string) (*string, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	digits, err := validateCNPJ(text)
+	if err != nil {
+		return nil, err
+	}
+	return &digits, nil
+}
+
+func displayCNPJ(cnpj *string) string {
+	if cnpj == nil {
+		return ""
+	}
+	return formatCNPJ(*cnpj)
+}
+
+func formatPhone(digits string) string {
+	if len(digits) > 11 {
+		digits = digits[:11]
+	}
+	n := len(digits)
+	switch {
+	case n == 0:
+		return ""
+	case n <= 2:
+		return "(" + digits
+	case n <= 6:
+		return fmt.Sprintf("(%s) %s", digits[:2], digits[2:])
+	case n <= 10:
+		return fmt.Sprintf("(%s) %s-%s", digits[:2], digits[2:6], digits[6:])
+	default:
+		return fmt.Sprintf("(%s) %s-%s", digits[:2], digits[2:7], digits[7:])
+	}
+}
+
+func validatePhone(phone string) (string, error) {
+	digits := onlyDigits(phone)
+	if digits == "" {
+		return "", nil
+	}
+	if len(digits) != 10 && len(digits) != 11 {
+		return "", errors.New(T("validation.phone_length"))
+	}
+	if digits[0] == '0' || digits[1] == '0' {
+		return "", errors.New(T("validation.phone_area_code"))
+	}
+	if len(digits) == 11 && digits[2] != '9' {
+		return "", errors.New(T("validation.phone_mobile"))
+	}
+	return digits, nil
+}
+
+func validateLength(field, value string, max int) error {
+	if n := utf8.RuneCountInString(strings.TrimSpace(value)); n > max {
+		return errors.New(T("common.too_long", field, max, n))
+	}
+	return nil
+}
+
+func limitLength(entry *widget.Entry, field string, max int) {
+	entry.Validator = func(text string) error {
+		return validateLength(field, text, max)
+	}
+}
+
+func checkLengths(entries ...*widget.Entry) error {
+	for _, e := range entries {
+		if e.Validator == nil {
+			continue
+		}
+		if err := e.Validator(e.Text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email || addr.Name != "" {
+		return "", errors.New(T("common.invalid_email"))
+	}
+	domain := email[strings.LastIndex(email, "@")+1:]
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 {
+		return "", errors.New(T("common.invalid_email"))
+	}
+	for _, label := range labels {
+		if label == "" || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return "", errors.New(T("common.invalid_email"))
+		}
+	}
+	return email, nil
+}
+
+func displayPhone(phone string) string {
+	return formatPhone(onlyDigits(phone))
+}
+
+func applyPhoneMask(entry *widget.Entry) {
+	entry.SetPlaceHolder("(00) 00000-0000")
+	entry.OnChanged = func(text string) {
+		formatted := formatPhone(onlyDigits(text))
+		if formatted != text {
+			entry.SetText(formatted)
+			entry.CursorColumn = len([]rune(formatted))
+			entry.Refresh()
+		}
+	}
+}